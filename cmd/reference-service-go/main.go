@@ -15,6 +15,7 @@
 	"reference-service-go/internal/outgoing/pokeapi"
 	"reference-service-go/internal/outgoing/referencepg"
 	"reference-service-go/internal/outgoing/tracing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/monkescience/vital"
@@ -50,6 +51,8 @@ func main() {
 func run() error {
 	configPath := flag.String("config", "/config/config.yaml", "Path to the configuration file")
 	migrateOnly := flag.Bool("migrate-only", false, "Run database migrations and exit")
+	exportSnapshotPath := flag.String("export-snapshot", "", "Export the pokemon catalog to a snapshot file and exit")
+	loadSnapshotPath := flag.String("load-snapshot", "", "Load a snapshot file into an empty catalog and exit")
 
 	flag.Parse()
 
@@ -79,6 +82,10 @@ func run() error {
 		return nil
 	}
 
+	if *exportSnapshotPath != "" || *loadSnapshotPath != "" {
+		return runSnapshotCommand(cfg, logger, *exportSnapshotPath, *loadSnapshotPath)
+	}
+
 	return runServer(cfg, logger)
 }
 
@@ -102,23 +109,66 @@ func runServer(cfg *config.Config, logger *slog.Logger) error {
 
 	defer store.Close()
 
-	pokeapiClient, err := pokeapi.NewFetcher(
-		&http.Client{
-			Timeout:   cfg.PokeAPI.Timeout,
-			Transport: otelhttp.NewTransport(http.DefaultTransport),
+	if cfg.Snapshot.LoadPath != "" {
+		err = seedFromSnapshot(ctx, logger, pokemon.NewSnapshots(store, store), cfg.Snapshot.LoadPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	sources, breakerChecks, err := newImportSources(cfg, store)
+	if err != nil {
+		return err
+	}
+
+	if cfg.PokeAPI.CacheEnabled {
+		evictor := pokeapi.NewCacheEvictor(store, cfg.PokeAPI.CacheMaxAge)
+		evictor.Start(ctx)
+
+		defer evictor.Shutdown()
+	}
+
+	pokemonService := pokemon.NewService(
+		sources,
+		pokemon.Stores{
+			Imports:      store,
+			Queue:        store,
+			Stale:        store,
+			ImportErrors: store,
+			Uploads:      store,
+			Diffs:        store,
+			Catalog:      store,
+			History:      store,
+		},
+		cfg.PokeAPI.Concurrency,
+		pokemon.WorkerConfig{
+			PollInterval:      cfg.Imports.PollInterval,
+			LeaseDuration:     cfg.Imports.LeaseDuration,
+			HeartbeatInterval: cfg.Imports.HeartbeatInterval,
+			FlushInterval:     cfg.Imports.FlushInterval,
+			DrainTimeout:      cfg.Imports.DrainTimeout,
+			StaleAfter:        cfg.Imports.StaleAfter,
+			SweepInterval:     cfg.Imports.SweepInterval,
+			MaxResumes:        cfg.Imports.MaxResumes,
 		},
-		cfg.PokeAPI.BaseURL,
 	)
+
+	pokemonService.Start(ctx)
+
+	defer pokemonService.Shutdown()
+
+	schedule, err := newImportSchedule(cfg)
 	if err != nil {
-		return fmt.Errorf("creating pokeapi client: %w", err)
+		return err
 	}
 
-	pokemonService := pokemon.NewService(pokeapiClient, store, store, cfg.PokeAPI.Concurrency)
+	scheduler := pokemon.NewScheduler(pokemonService, store, schedule)
+	scheduler.Start(ctx)
 
-	defer pokemonService.Shutdown()
+	defer scheduler.Shutdown()
 
 	catchService := catch.NewService(store, store, catch.DefaultRand{})
-	router := setupRouter(logger, pokemonService, catchService)
+	router := setupRouter(logger, pokemonService, catchService, breakerChecks)
 
 	server := vital.NewServer(
 		router,
@@ -139,10 +189,86 @@ func runServer(cfg *config.Config, logger *slog.Logger) error {
 	return nil
 }
 
+// newImportSources creates a fetcher for every configured import source, along
+// with health checks reporting the state of their circuit breakers.
+func newImportSources(cfg *config.Config, cache pokeapi.ResponseCache) (pokemon.Sources, []vital.Checker, error) {
+	sources := make(pokemon.Sources)
+	checks := make([]vital.Checker, 0, len(cfg.ImportSources()))
+
+	for name, sourceCfg := range cfg.ImportSources() {
+		breaker := pokeapi.NewBreaker(pokeapi.BreakerConfig{
+			FailureThreshold: cfg.PokeAPI.BreakerFailures,
+			Cooldown:         cfg.PokeAPI.BreakerCooldown,
+		})
+
+		// The retrying transport runs inside the otelhttp span, so retries are
+		// recorded as events of the request span. The breaker sees a request
+		// only once its retries are exhausted.
+		transport := pokeapi.NewTransport(http.DefaultTransport, pokeapi.TransportConfig{
+			RateLimit:      cfg.PokeAPI.RateLimit,
+			Burst:          cfg.PokeAPI.RateBurst,
+			MaxRetries:     cfg.PokeAPI.MaxRetries,
+			RetryBaseDelay: cfg.PokeAPI.RetryBaseDelay,
+			RetryMaxDelay:  cfg.PokeAPI.RetryMaxDelay,
+		})
+
+		transport = breaker.Transport(transport)
+
+		// Cached responses are served without passing the breaker, so imports
+		// can still read them while a source is down.
+		if cfg.PokeAPI.CacheEnabled {
+			transport = pokeapi.NewCachingTransport(transport, cache, pokeapi.CacheConfig{
+				TTL:     cfg.PokeAPI.CacheTTL,
+				ListTTL: cfg.PokeAPI.CacheListTTL,
+			})
+		}
+
+		fetcher, err := pokeapi.NewFetcher(
+			&http.Client{
+				Timeout:   sourceCfg.Timeout,
+				Transport: otelhttp.NewTransport(transport),
+			},
+			sourceCfg.BaseURL,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating fetcher for import source %s: %w", name, err)
+		}
+
+		sources[name] = fetcher
+		checks = append(checks, breakerCheck{source: name, breaker: breaker})
+	}
+
+	return sources, checks, nil
+}
+
+// newImportSchedule turns the configured schedule into scheduled imports.
+func newImportSchedule(cfg *config.Config) ([]pokemon.ScheduledImport, error) {
+	jobs := make([]pokemon.ScheduledImport, 0, len(cfg.Imports.Schedule))
+
+	for name, scheduleCfg := range cfg.Imports.Schedule {
+		schedule, err := scheduleCfg.ParseCron()
+		if err != nil {
+			return nil, fmt.Errorf("parsing import schedule %s: %w", name, err)
+		}
+
+		jobs = append(jobs, pokemon.ScheduledImport{
+			Name:     name,
+			Schedule: schedule,
+			Params: pokemon.CreateImportParams{
+				Source: scheduleCfg.Source,
+				Mode:   pokemon.ImportMode(scheduleCfg.Mode),
+			},
+		})
+	}
+
+	return jobs, nil
+}
+
 func setupRouter(
 	logger *slog.Logger,
 	pokemonService *pokemon.Service,
 	catchService *catch.Service,
+	healthChecks []vital.Checker,
 ) chi.Router {
 	router := chi.NewRouter()
 	router.Use(vital.Recovery(logger))
@@ -154,8 +280,40 @@ func setupRouter(
 
 	healthHandler := vital.NewHealthHandler(
 		vital.WithVersion(build.Version()),
+		vital.WithCheckers(healthChecks...),
 	)
 	router.Mount("/health", healthHandler)
 
 	return router
 }
+
+// breakerCheck reports the circuit breaker state of an import source.
+//
+// An open breaker only fails imports from its source, so the check stays ok
+// and the service stays ready.
+type breakerCheck struct {
+	source  string
+	breaker *pokeapi.Breaker
+}
+
+// Name names the check after its import source.
+func (c breakerCheck) Name() string {
+	return "import_source_" + c.source
+}
+
+// Check reports the breaker state as the check message.
+func (c breakerCheck) Check(_ context.Context) (vital.Status, string) {
+	status := c.breaker.Status()
+
+	switch status.State {
+	case pokeapi.BreakerOpen:
+		return vital.StatusOK, fmt.Sprintf("circuit breaker open after %d consecutive failures until %s",
+			status.ConsecutiveFailures, status.OpenUntil.Format(time.RFC3339))
+	case pokeapi.BreakerHalfOpen:
+		return vital.StatusOK, "circuit breaker half open"
+	case pokeapi.BreakerClosed:
+		return vital.StatusOK, "circuit breaker closed"
+	default:
+		return vital.StatusOK, "circuit breaker " + string(status.State)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reference-service-go/internal/config"
+	"reference-service-go/internal/core/pokemon"
+	"reference-service-go/internal/outgoing/referencepg"
+	"reference-service-go/internal/outgoing/snapshotfile"
+)
+
+var errSnapshotFlags = errors.New("-export-snapshot and -load-snapshot cannot be combined")
+
+// runSnapshotCommand exports the catalog to exportPath or loads the snapshot at
+// loadPath into an empty catalog, and returns.
+func runSnapshotCommand(cfg *config.Config, logger *slog.Logger, exportPath, loadPath string) error {
+	if exportPath != "" && loadPath != "" {
+		return errSnapshotFlags
+	}
+
+	ctx := context.Background()
+
+	databaseURL, err := cfg.Database.URL()
+	if err != nil {
+		return fmt.Errorf("loading database url: %w", err)
+	}
+
+	store, err := referencepg.New(ctx, databaseURL)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+
+	defer store.Close()
+
+	snapshots := pokemon.NewSnapshots(store, store)
+
+	if exportPath != "" {
+		return exportSnapshot(ctx, logger, snapshots, exportPath)
+	}
+
+	return loadSnapshot(ctx, logger, snapshots, loadPath)
+}
+
+// seedFromSnapshot loads the configured snapshot at startup if the catalog is
+// still empty.
+func seedFromSnapshot(ctx context.Context, logger *slog.Logger, snapshots *pokemon.Snapshots, path string) error {
+	err := loadSnapshot(ctx, logger, snapshots, path)
+	if errors.Is(err, pokemon.ErrCatalogNotEmpty) {
+		logger.InfoContext(ctx, "catalog is not empty, skipping snapshot", slog.String("path", path))
+
+		return nil
+	}
+
+	return err
+}
+
+// exportSnapshot writes the catalog to a snapshot file. The file is written
+// next to its destination and renamed into place, so a failed export does not
+// leave a truncated snapshot behind.
+func exportSnapshot(ctx context.Context, logger *slog.Logger, snapshots *pokemon.Snapshots, path string) error {
+	snapshot, err := snapshots.Export(ctx)
+	if err != nil {
+		return fmt.Errorf("exporting snapshot: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating snapshot file: %w", err)
+	}
+
+	defer os.Remove(f.Name()) //nolint:errcheck // The file is gone once it was renamed.
+
+	err = snapshotfile.Write(f, snapshot)
+	if err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("writing snapshot file: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("closing snapshot file: %w", err)
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return fmt.Errorf("renaming snapshot file: %w", err)
+	}
+
+	logger.InfoContext(ctx, "snapshot exported",
+		slog.String("path", path),
+		slog.Int("pokemon", len(snapshot.Pokemon)),
+	)
+
+	return nil
+}
+
+// loadSnapshot seeds an empty catalog from a snapshot file.
+func loadSnapshot(ctx context.Context, logger *slog.Logger, snapshots *pokemon.Snapshots, path string) error {
+	//nolint:gosec // Snapshot paths come from trusted flags and deployment configuration.
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening snapshot file: %w", err)
+	}
+
+	defer f.Close() //nolint:errcheck // The file is only read.
+
+	snapshot, err := snapshotfile.Read(f)
+	if err != nil {
+		return fmt.Errorf("reading snapshot file %s: %w", path, err)
+	}
+
+	err = snapshots.Load(ctx, snapshot)
+	if err != nil {
+		return fmt.Errorf("loading snapshot %s: %w", path, err)
+	}
+
+	logger.InfoContext(ctx, "snapshot loaded",
+		slog.String("path", path),
+		slog.Int("pokemon", len(snapshot.Pokemon)),
+		slog.Time("created_at", snapshot.CreatedAt),
+	)
+
+	return nil
+}
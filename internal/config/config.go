@@ -3,10 +3,13 @@
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"go.yaml.in/yaml/v4"
 )
 
@@ -23,7 +26,46 @@
 	errPokeAPIBaseURLEmpty    = errors.New("pokeapi.base_url must not be empty")
 	errPokeAPITimeoutZero     = errors.New("pokeapi.timeout must not be zero")
 	errPokeAPIConcurrencyZero = errors.New("pokeapi.concurrency must not be zero")
+	errPokeAPIRateLimit       = errors.New("pokeapi.rate_limit must not be negative")
+	errPokeAPIRateBurst       = errors.New("pokeapi.rate_burst must be positive when pokeapi.rate_limit is set")
+	errPokeAPIMaxRetries      = errors.New("pokeapi.max_retries must not be negative")
+	errPokeAPIRetryBaseZero   = errors.New("pokeapi.retry_base_delay must not be zero when pokeapi.max_retries is set")
+	errPokeAPIRetryMaxDelay   = errors.New("pokeapi.retry_max_delay must not be shorter than pokeapi.retry_base_delay")
+	errPokeAPIBreakerFailures = errors.New("pokeapi.breaker_failures must not be negative")
+	errPokeAPIBreakerCooldown = errors.New("pokeapi.breaker_cooldown must not be zero when breaker_failures is set")
+	errPokeAPICacheTTL        = errors.New("pokeapi.cache_ttl must not be negative")
+	errPokeAPICacheListTTL    = errors.New("pokeapi.cache_list_ttl must not be negative")
+	errPokeAPICacheMaxAge     = errors.New("pokeapi.cache_max_age must not be negative")
 	errOTelEndpointEmpty      = errors.New("otel.endpoint must not be empty when otel.enabled is true")
+	errImportsPollZero        = errors.New("imports.poll_interval must not be zero")
+	errImportsLeaseZero       = errors.New("imports.lease_duration must not be zero")
+	errImportsHeartbeatZero   = errors.New("imports.heartbeat_interval must not be zero")
+	errImportsHeartbeatLease  = errors.New("imports.heartbeat_interval must be shorter than imports.lease_duration")
+	errImportsFlushZero       = errors.New("imports.flush_interval must not be zero")
+	errImportsDrainTimeout    = errors.New("imports.drain_timeout must not be negative")
+	errImportsStaleAfter      = errors.New("imports.stale_after must be longer than imports.lease_duration")
+	errImportsSweepZero       = errors.New("imports.sweep_interval must not be zero")
+	errImportsMaxResumes      = errors.New("imports.max_resumes must not be negative")
+	errImportsSourceNameEmpty = errors.New("imports.sources names must not be empty")
+	errImportsSourceReserved  = errors.New("imports.sources must not redefine the built-in source")
+	errImportsSourceType      = errors.New("imports.sources type must be " + SourceTypePokeAPI)
+	errImportsSourceBaseURL   = errors.New("imports.sources base_url must not be empty")
+	errImportsScheduleCron    = errors.New("imports.schedule cron is invalid")
+	errImportsScheduleSource  = errors.New("imports.schedule source must name a configured source")
+	errImportsScheduleMode    = errors.New("imports.schedule mode must be full or incremental")
+)
+
+const (
+	// SourceTypePokeAPI reads from a PokeAPI-compatible API.
+	SourceTypePokeAPI = "pokeapi"
+
+	// defaultSourceName names the source built from the pokeapi section.
+	defaultSourceName = "pokeapi"
+	// fileSourceName is the built-in source of uploaded imports.
+	fileSourceName = "file"
+
+	importModeFull        = "full"
+	importModeIncremental = "incremental"
 )
 
 // Config holds the application configuration.
@@ -37,6 +79,8 @@ type Config struct {
 	Server   ServerConfig   `yaml:"server"`
 	Database DatabaseConfig `yaml:"database"`
 	PokeAPI  PokeAPIConfig  `yaml:"pokeapi"`
+	Imports  ImportsConfig  `yaml:"imports"`
+	Snapshot SnapshotConfig `yaml:"snapshot"`
 	OTel     OTelConfig     `yaml:"otel"`
 }
 
@@ -46,6 +90,14 @@ type OTelConfig struct {
 	Endpoint string `yaml:"endpoint"`
 }
 
+// SnapshotConfig holds settings for catalog snapshots.
+//
+// With a LoadPath, the service seeds an empty catalog from the snapshot file at
+// startup. A catalog that already holds Pokemon is left as it is.
+type SnapshotConfig struct {
+	LoadPath string `yaml:"load_path"`
+}
+
 // ServerConfig holds HTTP server settings.
 type ServerConfig struct {
 	Port            int           `yaml:"port"`
@@ -64,10 +116,105 @@ type DatabaseConfig struct {
 }
 
 // PokeAPIConfig holds settings for the PokeAPI client.
+//
+// Requests to each source are limited to RateLimit per second, with bursts of
+// up to RateBurst. Responses with status 429 or a transient 5xx and transport
+// errors are retried up to MaxRetries times with exponential backoff between
+// RetryBaseDelay and RetryMaxDelay, or after the server's Retry-After delay.
+// The timeout covers a request including its retries. A zero rate limit or
+// zero max retries disables rate limiting or retries.
+//
+// After BreakerFailures consecutive failed requests to a source its circuit
+// breaker opens: imports from that source fail right away until a probe request
+// succeeds after BreakerCooldown. Zero breaker failures disables the breaker.
+//
+// With CacheEnabled, raw responses are cached in PostgreSQL. Single pokemon and
+// species are served from the cache for CacheTTL and lists, such as the species
+// count, for CacheListTTL. Older responses are revalidated with conditional
+// requests. Responses neither fetched nor revalidated for CacheMaxAge are
+// evicted; zero keeps them forever.
 type PokeAPIConfig struct {
-	BaseURL     string        `yaml:"base_url"`
-	Timeout     time.Duration `yaml:"timeout"`
-	Concurrency int           `yaml:"concurrency"`
+	BaseURL         string        `yaml:"base_url"`
+	Timeout         time.Duration `yaml:"timeout"`
+	Concurrency     int           `yaml:"concurrency"`
+	RateLimit       float64       `yaml:"rate_limit"`
+	RateBurst       int           `yaml:"rate_burst"`
+	MaxRetries      int           `yaml:"max_retries"`
+	RetryBaseDelay  time.Duration `yaml:"retry_base_delay"`
+	RetryMaxDelay   time.Duration `yaml:"retry_max_delay"`
+	BreakerFailures int           `yaml:"breaker_failures"`
+	BreakerCooldown time.Duration `yaml:"breaker_cooldown"`
+	CacheEnabled    bool          `yaml:"cache_enabled"`
+	CacheTTL        time.Duration `yaml:"cache_ttl"`
+	CacheListTTL    time.Duration `yaml:"cache_list_ttl"`
+	CacheMaxAge     time.Duration `yaml:"cache_max_age"`
+}
+
+// ImportsConfig holds settings for the durable import worker.
+//
+// A worker holds a lease on the import it runs and renews it every heartbeat
+// interval. Imports whose lease expired are reclaimed by any replica. Fetched
+// Pokemon are written in batches, at the latest every flush interval.
+//
+// On shutdown, running imports get up to DrainTimeout to write the species
+// they already fetched. They are then marked interrupted and resumed by
+// another replica. A zero drain timeout interrupts them right away.
+//
+// Every SweepInterval, and once at startup, processing imports whose lease
+// expired and that went without an update for StaleAfter are recovered. Such
+// an import is resumed from its checkpoint up to MaxResumes times, counting
+// reclaimed leases, and then failed with a reason.
+//
+// Settings left out of the config file take the values of DefaultImports.
+type ImportsConfig struct {
+	PollInterval      time.Duration `yaml:"poll_interval"`
+	LeaseDuration     time.Duration `yaml:"lease_duration"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	FlushInterval     time.Duration `yaml:"flush_interval"`
+	DrainTimeout      time.Duration `yaml:"drain_timeout"`
+	StaleAfter        time.Duration `yaml:"stale_after"`
+	SweepInterval     time.Duration `yaml:"sweep_interval"`
+	MaxResumes        int           `yaml:"max_resumes"`
+	// Sources names the sources imports can fetch from. See Config.ImportSources.
+	Sources map[string]ImportSourceConfig `yaml:"sources"`
+	// Schedule names the imports created on a recurring schedule.
+	Schedule map[string]ImportScheduleConfig `yaml:"schedule"`
+}
+
+// ImportScheduleConfig holds a recurring import.
+//
+// Cron takes a standard five-field expression, optionally preceded by a seconds
+// field and a CRON_TZ= time zone, or a descriptor such as @daily or @every 6h.
+// An empty mode means a full import.
+type ImportScheduleConfig struct {
+	Cron   string `yaml:"cron"`
+	Source string `yaml:"source"`
+	Mode   string `yaml:"mode"`
+}
+
+// ImportSourceConfig holds settings for a named import source.
+//
+// Sources of type pokeapi read from any PokeAPI-compatible API, such as PokeAPI
+// itself or a local mirror. A zero timeout falls back to pokeapi.timeout.
+type ImportSourceConfig struct {
+	Type    string        `yaml:"type"`
+	BaseURL string        `yaml:"base_url"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DefaultImports returns the import worker settings of a config file without
+// an imports section.
+func DefaultImports() ImportsConfig {
+	return ImportsConfig{
+		PollInterval:      5 * time.Second,
+		LeaseDuration:     time.Minute,
+		HeartbeatInterval: 15 * time.Second,
+		FlushInterval:     2 * time.Second,
+		DrainTimeout:      5 * time.Second,
+		StaleAfter:        5 * time.Minute,
+		SweepInterval:     time.Minute,
+		MaxResumes:        3,
+	}
 }
 
 // Load reads configuration from the specified YAML file.
@@ -85,7 +232,9 @@ func Load(path string) (_ *Config, err error) {
 		}
 	}()
 
-	var cfg Config
+	// The file only overrides the defaults it sets, so a value it sets to zero
+	// is still validated.
+	cfg := Config{Imports: DefaultImports()}
 
 	decoder := yaml.NewDecoder(configFile)
 
@@ -112,6 +261,44 @@ func (d DatabaseConfig) URL() (string, error) {
 	return value, nil
 }
 
+// ImportSources returns the configured import sources by name.
+//
+// Without an imports.sources section the pokeapi section is the only source,
+// named pokeapi.
+func (c Config) ImportSources() map[string]ImportSourceConfig {
+	if len(c.Imports.Sources) == 0 {
+		return map[string]ImportSourceConfig{
+			defaultSourceName: {Type: SourceTypePokeAPI, BaseURL: c.PokeAPI.BaseURL, Timeout: c.PokeAPI.Timeout},
+		}
+	}
+
+	sources := make(map[string]ImportSourceConfig, len(c.Imports.Sources))
+
+	for name, source := range c.Imports.Sources {
+		if source.Timeout == 0 {
+			source.Timeout = c.PokeAPI.Timeout
+		}
+
+		sources[name] = source
+	}
+
+	return sources
+}
+
+// ParseCron parses the cron expression of the scheduled import.
+func (c ImportScheduleConfig) ParseCron() (cron.Schedule, error) {
+	parser := cron.NewParser(
+		cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+	)
+
+	schedule, err := parser.Parse(c.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("parse cron %q: %w", c.Cron, err)
+	}
+
+	return schedule, nil
+}
+
 // Validate checks that required configuration values are present.
 func (c Config) Validate() error {
 	var err error
@@ -148,20 +335,166 @@ func (c Config) Validate() error {
 		err = errors.Join(err, errDatabaseURLEnvEmpty)
 	}
 
-	if strings.TrimSpace(c.PokeAPI.BaseURL) == "" {
+	err = errors.Join(err, c.PokeAPI.validate())
+	err = errors.Join(err, c.Imports.validate())
+	err = errors.Join(err, c.validateSchedule())
+
+	if c.OTel.Enabled && strings.TrimSpace(c.OTel.Endpoint) == "" {
+		err = errors.Join(err, errOTelEndpointEmpty)
+	}
+
+	return err
+}
+
+func (c PokeAPIConfig) validate() error {
+	var err error
+
+	if strings.TrimSpace(c.BaseURL) == "" {
 		err = errors.Join(err, errPokeAPIBaseURLEmpty)
 	}
 
-	if c.PokeAPI.Timeout == 0 {
+	if c.Timeout == 0 {
 		err = errors.Join(err, errPokeAPITimeoutZero)
 	}
 
-	if c.PokeAPI.Concurrency == 0 {
+	if c.Concurrency == 0 {
 		err = errors.Join(err, errPokeAPIConcurrencyZero)
 	}
 
-	if c.OTel.Enabled && strings.TrimSpace(c.OTel.Endpoint) == "" {
-		err = errors.Join(err, errOTelEndpointEmpty)
+	if c.RateLimit < 0 {
+		err = errors.Join(err, errPokeAPIRateLimit)
+	}
+
+	if c.RateLimit > 0 && c.RateBurst <= 0 {
+		err = errors.Join(err, errPokeAPIRateBurst)
+	}
+
+	if c.MaxRetries < 0 {
+		err = errors.Join(err, errPokeAPIMaxRetries)
+	}
+
+	if c.MaxRetries > 0 && c.RetryBaseDelay == 0 {
+		err = errors.Join(err, errPokeAPIRetryBaseZero)
+	}
+
+	if c.MaxRetries > 0 && c.RetryMaxDelay < c.RetryBaseDelay {
+		err = errors.Join(err, errPokeAPIRetryMaxDelay)
+	}
+
+	if c.BreakerFailures < 0 {
+		err = errors.Join(err, errPokeAPIBreakerFailures)
+	}
+
+	if c.BreakerFailures > 0 && c.BreakerCooldown == 0 {
+		err = errors.Join(err, errPokeAPIBreakerCooldown)
+	}
+
+	if c.CacheTTL < 0 {
+		err = errors.Join(err, errPokeAPICacheTTL)
+	}
+
+	if c.CacheListTTL < 0 {
+		err = errors.Join(err, errPokeAPICacheListTTL)
+	}
+
+	if c.CacheMaxAge < 0 {
+		err = errors.Join(err, errPokeAPICacheMaxAge)
+	}
+
+	return err
+}
+
+func (c ImportsConfig) validate() error {
+	var err error
+
+	if c.PollInterval == 0 {
+		err = errors.Join(err, errImportsPollZero)
+	}
+
+	if c.LeaseDuration == 0 {
+		err = errors.Join(err, errImportsLeaseZero)
+	}
+
+	if c.HeartbeatInterval == 0 {
+		err = errors.Join(err, errImportsHeartbeatZero)
+	}
+
+	if c.LeaseDuration != 0 && c.HeartbeatInterval >= c.LeaseDuration {
+		err = errors.Join(err, errImportsHeartbeatLease)
+	}
+
+	if c.FlushInterval == 0 {
+		err = errors.Join(err, errImportsFlushZero)
+	}
+
+	if c.DrainTimeout < 0 {
+		err = errors.Join(err, errImportsDrainTimeout)
+	}
+
+	if c.StaleAfter <= c.LeaseDuration {
+		err = errors.Join(err, errImportsStaleAfter)
+	}
+
+	if c.SweepInterval == 0 {
+		err = errors.Join(err, errImportsSweepZero)
+	}
+
+	if c.MaxResumes < 0 {
+		err = errors.Join(err, errImportsMaxResumes)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Sources)) {
+		err = errors.Join(err, c.Sources[name].validate(name))
+	}
+
+	return err
+}
+
+// validateSchedule checks the scheduled imports against the configured sources.
+func (c Config) validateSchedule() error {
+	var err error
+
+	sources := c.ImportSources()
+
+	for _, name := range slices.Sorted(maps.Keys(c.Imports.Schedule)) {
+		schedule := c.Imports.Schedule[name]
+
+		_, cronErr := schedule.ParseCron()
+		if cronErr != nil {
+			err = errors.Join(err, fmt.Errorf("%w: %s: %w", errImportsScheduleCron, name, cronErr))
+		}
+
+		if _, ok := sources[schedule.Source]; !ok {
+			err = errors.Join(err, fmt.Errorf("%w: %s", errImportsScheduleSource, name))
+		}
+
+		switch schedule.Mode {
+		case "", importModeFull, importModeIncremental:
+		default:
+			err = errors.Join(err, fmt.Errorf("%w: %s", errImportsScheduleMode, name))
+		}
+	}
+
+	return err
+}
+
+func (c ImportSourceConfig) validate(name string) error {
+	var err error
+
+	if strings.TrimSpace(name) == "" {
+		err = errors.Join(err, errImportsSourceNameEmpty)
+	}
+
+	if name == fileSourceName {
+		err = errors.Join(err, fmt.Errorf("%w: %s", errImportsSourceReserved, name))
+	}
+
+	if c.Type != SourceTypePokeAPI {
+		err = errors.Join(err, fmt.Errorf("%w: %s", errImportsSourceType, name))
+	}
+
+	if strings.TrimSpace(c.BaseURL) == "" {
+		err = errors.Join(err, fmt.Errorf("%w: %s", errImportsSourceBaseURL, name))
 	}
 
 	return err
@@ -4,6 +4,7 @@
 	"os"
 	"reference-service-go/internal/config"
 	"testing"
+	"time"
 
 	"github.com/monkescience/testastic"
 )
@@ -28,6 +29,25 @@ func TestLoad(t *testing.T) {
 		testastic.Equal(t, "2m0s", cfg.Server.IdleTimeout.String())
 		testastic.Equal(t, "20s", cfg.Server.ShutdownTimeout.String())
 		testastic.Equal(t, "DATABASE_URL", cfg.Database.URLEnv)
+		testastic.Equal(t, 20.0, cfg.PokeAPI.RateLimit)
+		testastic.Equal(t, 10, cfg.PokeAPI.RateBurst)
+		testastic.Equal(t, 3, cfg.PokeAPI.MaxRetries)
+		testastic.Equal(t, "250ms", cfg.PokeAPI.RetryBaseDelay.String())
+		testastic.Equal(t, "5s", cfg.PokeAPI.RetryMaxDelay.String())
+		testastic.Equal(t, 5, cfg.PokeAPI.BreakerFailures)
+		testastic.Equal(t, "30s", cfg.PokeAPI.BreakerCooldown.String())
+		testastic.True(t, cfg.PokeAPI.CacheEnabled)
+		testastic.Equal(t, "168h0m0s", cfg.PokeAPI.CacheTTL.String())
+		testastic.Equal(t, "1h0m0s", cfg.PokeAPI.CacheListTTL.String())
+		testastic.Equal(t, "720h0m0s", cfg.PokeAPI.CacheMaxAge.String())
+		testastic.Equal(t, "5s", cfg.Imports.PollInterval.String())
+		testastic.Equal(t, "1m0s", cfg.Imports.LeaseDuration.String())
+		testastic.Equal(t, "15s", cfg.Imports.HeartbeatInterval.String())
+		testastic.Equal(t, "2s", cfg.Imports.FlushInterval.String())
+		testastic.Equal(t, "5s", cfg.Imports.DrainTimeout.String())
+		testastic.Equal(t, "5m0s", cfg.Imports.StaleAfter.String())
+		testastic.Equal(t, "1m0s", cfg.Imports.SweepInterval.String())
+		testastic.Equal(t, 3, cfg.Imports.MaxResumes)
 		testastic.False(t, cfg.OTel.Enabled)
 		testastic.Equal(t, "localhost:4317", cfg.OTel.Endpoint)
 	})
@@ -59,6 +79,20 @@ func TestLoad(t *testing.T) {
   timeout: "15s"
   concurrency: 7
 
+imports:
+  poll_interval: "2s"
+  lease_duration: "30s"
+  heartbeat_interval: "10s"
+  flush_interval: "3s"
+  stale_after: "2m"
+  sweep_interval: "20s"
+  max_resumes: 1
+  sources:
+    mirror:
+      type: "pokeapi"
+      base_url: "http://mirror.example/api/v2"
+      timeout: "4s"
+
 otel:
   enabled: true
   endpoint: "otel.example:4317"
@@ -83,10 +117,62 @@ func TestLoad(t *testing.T) {
 		testastic.Equal(t, "https://pokeapi.example/api/v2", cfg.PokeAPI.BaseURL)
 		testastic.Equal(t, 7, cfg.PokeAPI.Concurrency)
 		testastic.Equal(t, "15s", cfg.PokeAPI.Timeout.String())
+		testastic.Equal(t, "2s", cfg.Imports.PollInterval.String())
+		testastic.Equal(t, "30s", cfg.Imports.LeaseDuration.String())
+		testastic.Equal(t, "10s", cfg.Imports.HeartbeatInterval.String())
+		testastic.Equal(t, "3s", cfg.Imports.FlushInterval.String())
+		testastic.Equal(t, "2m0s", cfg.Imports.StaleAfter.String())
+		testastic.Equal(t, "20s", cfg.Imports.SweepInterval.String())
+		testastic.Equal(t, 1, cfg.Imports.MaxResumes)
+		testastic.Equal(t, config.ImportSourceConfig{
+			Type:    "pokeapi",
+			BaseURL: "http://mirror.example/api/v2",
+			Timeout: 4 * time.Second,
+		}, cfg.Imports.Sources["mirror"])
 		testastic.True(t, cfg.OTel.Enabled)
 		testastic.Equal(t, "otel.example:4317", cfg.OTel.Endpoint)
 	})
 
+	t.Run("defaults the import worker settings", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config file without an imports section
+		configFile, err := os.CreateTemp(t.TempDir(), "config-*.yaml")
+		testastic.NoError(t, err)
+
+		_, err = configFile.WriteString(`log_config:
+  level: "info"
+  format: "text"
+
+server:
+  port: 8080
+  read_timeout: "10s"
+  write_timeout: "10s"
+  idle_timeout: "2m"
+  shutdown_timeout: "20s"
+
+database:
+  url_env: "DATABASE_URL"
+
+pokeapi:
+  base_url: "https://pokeapi.co/api/v2"
+  timeout: "10s"
+  concurrency: 4
+
+otel:
+  enabled: false
+`)
+		testastic.NoError(t, err)
+		testastic.NoError(t, configFile.Close())
+
+		// when: loading the config file
+		cfg, err := config.Load(configFile.Name())
+
+		// then: the import worker runs with the default settings
+		testastic.NoError(t, err)
+		testastic.DeepEqual(t, config.DefaultImports(), cfg.Imports)
+	})
+
 	t.Run("returns validation errors for missing required fields", func(t *testing.T) {
 		t.Parallel()
 
@@ -113,6 +199,13 @@ func TestLoad(t *testing.T) {
   timeout: "0s"
   concurrency: 0
 
+imports:
+  poll_interval: "0s"
+  lease_duration: "0s"
+  heartbeat_interval: "0s"
+  flush_interval: "0s"
+  sweep_interval: "0s"
+
 otel:
   enabled: true
   endpoint: ""
@@ -133,9 +226,163 @@ func TestLoad(t *testing.T) {
 		testastic.Contains(t, err.Error(), "pokeapi.base_url")
 		testastic.Contains(t, err.Error(), "pokeapi.timeout")
 		testastic.Contains(t, err.Error(), "pokeapi.concurrency")
+		testastic.Contains(t, err.Error(), "imports.poll_interval")
+		testastic.Contains(t, err.Error(), "imports.lease_duration")
+		testastic.Contains(t, err.Error(), "imports.heartbeat_interval")
+		testastic.Contains(t, err.Error(), "imports.flush_interval")
+		testastic.Contains(t, err.Error(), "imports.sweep_interval")
 		testastic.Contains(t, err.Error(), "otel.endpoint")
 	})
 
+	t.Run("rejects a heartbeat interval that is not shorter than the lease", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config whose heartbeat matches the lease duration
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.Imports.HeartbeatInterval = cfg.Imports.LeaseDuration
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it rejects the heartbeat interval
+		testastic.NotNil(t, err)
+		testastic.Contains(t, err.Error(), "imports.heartbeat_interval must be shorter")
+	})
+
+	t.Run("rejects a stale threshold within the lease and negative max resumes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config whose imports go stale before their lease expires
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.Imports.StaleAfter = cfg.Imports.LeaseDuration
+		cfg.Imports.MaxResumes = -1
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it rejects both settings
+		testastic.NotNil(t, err)
+		testastic.Contains(t, err.Error(), "imports.stale_after must be longer")
+		testastic.Contains(t, err.Error(), "imports.max_resumes must not be negative")
+	})
+
+	t.Run("rejects a negative drain timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config with a negative drain timeout
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.Imports.DrainTimeout = -time.Second
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it rejects the drain timeout
+		testastic.NotNil(t, err)
+		testastic.Contains(t, err.Error(), "imports.drain_timeout must not be negative")
+	})
+
+	t.Run("rejects invalid rate limit, retry, breaker and cache settings", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config with a burstless rate limit, a max delay below the base delay,
+		// a breaker without cooldown and a negative cache TTL and max age
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.PokeAPI.RateBurst = 0
+		cfg.PokeAPI.RetryMaxDelay = cfg.PokeAPI.RetryBaseDelay / 2
+		cfg.PokeAPI.BreakerCooldown = 0
+		cfg.PokeAPI.CacheTTL = -time.Hour
+		cfg.PokeAPI.CacheMaxAge = -time.Hour
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it rejects every setting
+		testastic.NotNil(t, err)
+		testastic.Contains(t, err.Error(), "pokeapi.rate_burst must be positive")
+		testastic.Contains(t, err.Error(), "pokeapi.retry_max_delay must not be shorter")
+		testastic.Contains(t, err.Error(), "pokeapi.breaker_cooldown must not be zero")
+		testastic.Contains(t, err.Error(), "pokeapi.cache_ttl must not be negative")
+		testastic.Contains(t, err.Error(), "pokeapi.cache_max_age must not be negative")
+	})
+
+	t.Run("allows disabling rate limiting, retries and the breaker", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config without rate limit and retry settings
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.PokeAPI.RateLimit = 0
+		cfg.PokeAPI.RateBurst = 0
+		cfg.PokeAPI.MaxRetries = 0
+		cfg.PokeAPI.RetryBaseDelay = 0
+		cfg.PokeAPI.RetryMaxDelay = 0
+		cfg.PokeAPI.BreakerFailures = 0
+		cfg.PokeAPI.BreakerCooldown = 0
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it accepts the config
+		testastic.NoError(t, err)
+	})
+
+	t.Run("rejects invalid import sources", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config with a reserved, an unknown and an incomplete source
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.Imports.Sources = map[string]config.ImportSourceConfig{
+			"file":   {Type: config.SourceTypePokeAPI, BaseURL: "https://pokeapi.example/api/v2"},
+			"ftp":    {Type: "ftp", BaseURL: "ftp://pokeapi.example"},
+			"mirror": {Type: config.SourceTypePokeAPI},
+		}
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it rejects every invalid source
+		testastic.NotNil(t, err)
+		testastic.Contains(t, err.Error(), "imports.sources must not redefine the built-in source: file")
+		testastic.Contains(t, err.Error(), "imports.sources type must be pokeapi: ftp")
+		testastic.Contains(t, err.Error(), "imports.sources base_url must not be empty: mirror")
+	})
+
+	t.Run("rejects invalid import schedules", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a valid config with schedules using a bad cron, an unknown source and an unknown mode
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.Imports.Schedule = map[string]config.ImportScheduleConfig{
+			"bad-cron":    {Cron: "every day", Source: "pokeapi"},
+			"bad-source":  {Cron: "@daily", Source: "mirror"},
+			"bad-mode":    {Cron: "0 3 * * *", Source: "pokeapi", Mode: "partial"},
+			"with-second": {Cron: "*/30 * * * * *", Source: "pokeapi", Mode: "incremental"},
+		}
+
+		// when: validating the config
+		err = cfg.Validate()
+
+		// then: it rejects every invalid schedule
+		testastic.NotNil(t, err)
+		testastic.Contains(t, err.Error(), "imports.schedule cron is invalid: bad-cron")
+		testastic.Contains(t, err.Error(), "imports.schedule source must name a configured source: bad-source")
+		testastic.Contains(t, err.Error(), "imports.schedule mode must be full or incremental: bad-mode")
+		testastic.NotContains(t, err.Error(), "with-second")
+	})
+
 	t.Run("returns error when config file does not exist", func(t *testing.T) {
 		t.Parallel()
 
@@ -147,6 +394,47 @@ func TestLoad(t *testing.T) {
 	})
 }
 
+func TestImportSources(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to the pokeapi section", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config without import sources
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		// when: resolving the import sources
+		sources := cfg.ImportSources()
+
+		// then: the pokeapi section is the only source
+		testastic.DeepEqual(t, map[string]config.ImportSourceConfig{
+			"pokeapi": {Type: config.SourceTypePokeAPI, BaseURL: cfg.PokeAPI.BaseURL, Timeout: cfg.PokeAPI.Timeout},
+		}, sources)
+	})
+
+	t.Run("fills in the pokeapi timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config with one source overriding the timeout and one without
+		cfg, err := config.Load("../../config/config.yaml")
+		testastic.NoError(t, err)
+
+		cfg.Imports.Sources = map[string]config.ImportSourceConfig{
+			"pokeapi": {Type: config.SourceTypePokeAPI, BaseURL: "https://pokeapi.co/api/v2"},
+			"mirror":  {Type: config.SourceTypePokeAPI, BaseURL: "http://mirror/api/v2", Timeout: 5 * time.Second},
+		}
+
+		// when: resolving the import sources
+		sources := cfg.ImportSources()
+
+		// then: only the source without a timeout falls back to the pokeapi timeout
+		testastic.NoError(t, cfg.Validate())
+		testastic.Equal(t, cfg.PokeAPI.Timeout, sources["pokeapi"].Timeout)
+		testastic.Equal(t, 5*time.Second, sources["mirror"].Timeout)
+	})
+}
+
 func TestDatabaseURL(t *testing.T) {
 	t.Run("reads the database url from the configured environment variable", func(t *testing.T) {
 		t.Setenv("TEST_DATABASE_URL", "postgres://localhost:5432/app")
@@ -0,0 +1,67 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// diffBatch compares a batch of a dry-run import with the catalog and records
+// the diff of every species. It counts the species like UpsertPokemonBatch
+// would have written them.
+func (s *Service) diffBatch(ctx context.Context, importID uuid.UUID, fetched []Pokemon) (UpsertCounts, error) {
+	ids := make([]int, 0, len(fetched))
+	for _, p := range fetched {
+		ids = append(ids, p.PokedexID)
+	}
+
+	current, err := s.catalog.ListPokemonByIDs(ctx, ids)
+	if err != nil {
+		return UpsertCounts{}, fmt.Errorf("listing current pokemon: %w", err)
+	}
+
+	stored := make(map[int]Pokemon, len(current))
+	for _, p := range current {
+		stored[p.PokedexID] = p
+	}
+
+	var (
+		counts UpsertCounts
+		diffs  []SpeciesDiff
+	)
+
+	for _, p := range fetched {
+		old, ok := stored[p.PokedexID]
+		if !ok {
+			counts.Inserted++
+			diffs = append(diffs, SpeciesDiff{PokedexID: p.PokedexID, Name: p.Name, Kind: DiffKindNew})
+
+			continue
+		}
+
+		changes := old.Diff(p)
+
+		// Writing an inactive species reactivates it, even if nothing else changed.
+		if old.DeletedAt != nil {
+			changes = append(changes, FieldChange{Field: "deleted_at", Old: *old.DeletedAt, New: nil})
+		}
+
+		if len(changes) == 0 {
+			counts.Unchanged++
+			diffs = append(diffs, SpeciesDiff{PokedexID: p.PokedexID, Name: p.Name, Kind: DiffKindUnchanged})
+
+			continue
+		}
+
+		counts.Updated++
+		diffs = append(diffs, SpeciesDiff{PokedexID: p.PokedexID, Name: p.Name, Kind: DiffKindChanged, Changes: changes})
+	}
+
+	err = s.diffs.RecordImportDiffs(ctx, importID, diffs)
+	if err != nil {
+		return UpsertCounts{}, fmt.Errorf("recording import diffs: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,74 @@
+package pokemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/monkescience/testastic"
+)
+
+// storedCatalog serves the Pokemon it holds by Pokedex ID.
+type storedCatalog struct {
+	CatalogStore
+
+	pokemon []Pokemon
+}
+
+func (c storedCatalog) ListPokemonByIDs(context.Context, []int) ([]Pokemon, error) {
+	return c.pokemon, nil
+}
+
+// fakeDiffs records the diffs of a dry run.
+type fakeDiffs struct {
+	ImportDiffStore
+
+	recorded []SpeciesDiff
+}
+
+func (d *fakeDiffs) RecordImportDiffs(_ context.Context, _ uuid.UUID, diffs []SpeciesDiff) error {
+	d.recorded = append(d.recorded, diffs...)
+
+	return nil
+}
+
+func TestDiffBatch(t *testing.T) {
+	t.Parallel()
+
+	// given: a catalog with an unchanged, an inactive and a rebalanced species
+	species := func(id, hp int) Pokemon {
+		return Pokemon{PokedexID: id, Name: "pokemon", Types: []string{"normal"}, Rarity: RarityCommon, HP: hp}
+	}
+
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	inactive := species(2, 50)
+	inactive.DeletedAt = &deletedAt
+
+	diffs := &fakeDiffs{}
+	service := &Service{
+		catalog: storedCatalog{pokemon: []Pokemon{species(1, 50), inactive, species(3, 50)}},
+		diffs:   diffs,
+	}
+
+	// when: a dry run fetches them unchanged, rebalanced and along with a new species
+	counts, err := service.diffBatch(t.Context(), uuid.New(), []Pokemon{
+		species(1, 50), species(2, 50), species(3, 60), species(4, 50),
+	})
+
+	// then: the inactive species is reported as reactivated, like the merge would write it
+	testastic.NoError(t, err)
+	testastic.Equal(t, UpsertCounts{Inserted: 1, Updated: 2, Unchanged: 1}, counts)
+	testastic.DeepEqual(t, []SpeciesDiff{
+		{PokedexID: 1, Name: "pokemon", Kind: DiffKindUnchanged},
+		{
+			PokedexID: 2, Name: "pokemon", Kind: DiffKindChanged,
+			Changes: []FieldChange{{Field: "deleted_at", Old: deletedAt, New: nil}},
+		},
+		{
+			PokedexID: 3, Name: "pokemon", Kind: DiffKindChanged,
+			Changes: []FieldChange{{Field: "hp", Old: 50, New: 60}},
+		},
+		{PokedexID: 4, Name: "pokemon", Kind: DiffKindNew},
+	}, diffs.recorded)
+}
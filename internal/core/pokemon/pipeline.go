@@ -0,0 +1,304 @@
+package pokemon
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+const batchSize = 50
+
+// fetchFunc fetches a single species of an import.
+type fetchFunc func(ctx context.Context, pokedexID int) (*Pokemon, error)
+
+// fetchedPokemon is a fetched Pokemon together with the target it was fetched for.
+type fetchedPokemon struct {
+	targetID int
+	pokemon  Pokemon
+}
+
+// streamImport fetches the given Pokedex IDs and streams the results through a
+// bounded channel into a batch writer, so only a few batches are held in memory
+// and progress is persisted while the import runs.
+func (s *Service) streamImport(ctx context.Context, imp Import, run *runningImport, ids []int, fetch fetchFunc) error {
+	g, gCtx := errgroup.WithContext(ctx)
+
+	results := make(chan fetchedPokemon, batchSize)
+	tracker := newCheckpointTracker(ids, imp.Checkpoint)
+
+	g.Go(func() error {
+		defer close(results)
+
+		return s.fetchAll(gCtx, run, ids, fetch, results, tracker)
+	})
+
+	g.Go(func() error {
+		progress := ImportProgress{ItemCount: imp.ItemCount, Checkpoint: imp.Checkpoint, Upserts: imp.Upserts}
+		writer := &batchWriter{
+			service:       s,
+			importID:      imp.ID,
+			skipUnchanged: imp.Mode == ImportModeIncremental,
+			dryRun:        imp.Mode == ImportModeDryRun,
+			tracker:       tracker,
+			progress:      progress,
+			saved:         progress,
+			batch:         make([]fetchedPokemon, 0, batchSize),
+		}
+
+		return writer.run(gCtx, results)
+	})
+
+	err := g.Wait()
+	if err != nil {
+		return err //nolint:wrapcheck // Both stages wrap their own errors.
+	}
+
+	// A draining import stops fetching early, once its last batch is written.
+	if tracker.remaining() > 0 {
+		return fmt.Errorf("stopped after pokedex id %d: %w", tracker.checkpoint(), ErrImportInterrupted)
+	}
+
+	return nil
+}
+
+func (s *Service) fetchAll(
+	ctx context.Context,
+	run *runningImport,
+	ids []int,
+	fetch fetchFunc,
+	results chan<- fetchedPokemon,
+	tracker *checkpointTracker,
+) error {
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(s.concurrency)
+
+	for _, pokemonID := range ids {
+		if gCtx.Err() != nil || run.draining() {
+			break
+		}
+
+		g.Go(func() error {
+			p, err := fetch(gCtx, pokemonID)
+			if err != nil {
+				if gCtx.Err() != nil {
+					return fmt.Errorf("fetching pokemon %d: %w", pokemonID, gCtx.Err())
+				}
+
+				if errors.Is(err, ErrSourceUnavailable) {
+					return fmt.Errorf("fetching pokemon %d: %w", pokemonID, err)
+				}
+
+				slog.WarnContext(gCtx, "skipping pokemon",
+					slog.Int("id", pokemonID),
+					slog.Any("error", err),
+				)
+
+				// The checkpoint only passes a skipped species once its error is
+				// stored, so a retry or resume still finds it.
+				err = s.importErrors.RecordImportError(gCtx, run.id, s.workerID, NewItemError(pokemonID, err))
+				if err != nil {
+					return fmt.Errorf("recording error of pokemon %d: %w", pokemonID, err)
+				}
+
+				tracker.markHandled(pokemonID)
+
+				return nil
+			}
+
+			run.fetched.Add(1)
+
+			select {
+			case results <- fetchedPokemon{targetID: pokemonID, pokemon: *p}:
+				return nil
+			case <-gCtx.Done():
+				return fmt.Errorf("fetching pokemon %d: %w", pokemonID, context.Cause(gCtx))
+			}
+		})
+	}
+
+	err := g.Wait()
+	if err != nil {
+		return inPhase(ImportPhaseFetch, fmt.Errorf("fetching pokemon: %w", err))
+	}
+
+	// Cancellation can stop scheduling before any fetch reports it.
+	if ctx.Err() != nil {
+		return inPhase(ImportPhaseFetch, fmt.Errorf("fetching pokemon: %w", context.Cause(ctx)))
+	}
+
+	return nil
+}
+
+// batchWriter upserts fetched Pokemon in batches, or diffs them against the
+// catalog for a dry run, and records the import's progress after every write.
+type batchWriter struct {
+	service  *Service
+	importID uuid.UUID
+	// skipUnchanged leaves rows whose content hash matches untouched.
+	skipUnchanged bool
+	// dryRun records the batches' diff against the catalog instead of upserting them.
+	dryRun   bool
+	tracker  *checkpointTracker
+	progress ImportProgress
+	// saved is the progress last persisted on the import.
+	saved ImportProgress
+	batch []fetchedPokemon
+}
+
+// run writes a batch once it is full or the flush interval elapsed, and the
+// remainder once the results channel is closed.
+func (w *batchWriter) run(ctx context.Context, results <-chan fetchedPokemon) error {
+	ticker := time.NewTicker(w.service.worker.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("writing pokemon: %w", context.Cause(ctx))
+		case item, ok := <-results:
+			if !ok {
+				return w.flush(ctx)
+			}
+
+			w.batch = append(w.batch, item)
+
+			if len(w.batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		err := w.flush(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (w *batchWriter) flush(ctx context.Context) error {
+	if len(w.batch) > 0 {
+		pokemon := make([]Pokemon, 0, len(w.batch))
+		targetIDs := make([]int, 0, len(w.batch))
+
+		for _, item := range w.batch {
+			pokemon = append(pokemon, item.pokemon)
+			targetIDs = append(targetIDs, item.targetID)
+		}
+
+		slices.SortFunc(pokemon, func(a, b Pokemon) int {
+			return cmp.Compare(a.PokedexID, b.PokedexID)
+		})
+
+		upserts, err := w.write(ctx, pokemon)
+		if err != nil {
+			return inPhase(ImportPhaseUpsert, err)
+		}
+
+		w.batch = w.batch[:0]
+		w.progress.ItemCount += len(pokemon)
+		w.progress.Upserts = w.progress.Upserts.Add(upserts)
+		w.tracker.markHandled(targetIDs...)
+	}
+
+	// Skipped species advance the checkpoint too, even without a write.
+	w.progress.Checkpoint = w.tracker.checkpoint()
+
+	if w.progress == w.saved {
+		return nil
+	}
+
+	// A committed batch is recorded even when the import was cancelled meanwhile.
+	err := w.service.queue.UpdateImportProgress(context.WithoutCancel(ctx), w.importID, w.service.workerID, w.progress)
+	if errors.Is(err, ErrImportLeaseLost) {
+		return err
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update import progress", slog.Any("error", err))
+
+		return nil
+	}
+
+	w.saved = w.progress
+
+	return nil
+}
+
+func (w *batchWriter) write(ctx context.Context, pokemon []Pokemon) (UpsertCounts, error) {
+	if w.dryRun {
+		upserts, err := w.service.diffBatch(ctx, w.importID, pokemon)
+		if err != nil {
+			return UpsertCounts{}, fmt.Errorf("diffing batch: %w", err)
+		}
+
+		return upserts, nil
+	}
+
+	upserts, err := w.service.catalog.UpsertPokemonBatch(ctx, w.importID, pokemon, w.skipUnchanged)
+	if err != nil {
+		return UpsertCounts{}, fmt.Errorf("upserting batch: %w", err)
+	}
+
+	return upserts, nil
+}
+
+// checkpointTracker derives an import's checkpoint from targets that finish
+// out of order. The checkpoint is the highest target up to which every target
+// was either persisted or recorded as failed.
+type checkpointTracker struct {
+	mu      sync.Mutex
+	pending []int
+	handled map[int]struct{}
+	current int
+}
+
+func newCheckpointTracker(ids []int, checkpoint int) *checkpointTracker {
+	return &checkpointTracker{
+		pending: ids,
+		handled: make(map[int]struct{}),
+		current: checkpoint,
+	}
+}
+
+func (t *checkpointTracker) markHandled(ids ...int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, id := range ids {
+		t.handled[id] = struct{}{}
+	}
+
+	for len(t.pending) > 0 {
+		next := t.pending[0]
+		if _, ok := t.handled[next]; !ok {
+			break
+		}
+
+		delete(t.handled, next)
+		t.pending = t.pending[1:]
+		t.current = next
+	}
+}
+
+// remaining returns the number of targets not handled yet.
+func (t *checkpointTracker) remaining() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.pending)
+}
+
+func (t *checkpointTracker) checkpoint() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.current
+}
@@ -0,0 +1,446 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/monkescience/testastic"
+)
+
+const testTimeout = 5 * time.Second
+
+var (
+	errCatalogDown = errors.New("catalog down")
+	errNotFound    = errors.New("not found")
+)
+
+// fakeQueue records the progress an import persists and how it failed.
+type fakeQueue struct {
+	ImportQueue
+
+	mu       sync.Mutex
+	progress []ImportProgress
+	failures []ImportFailure
+}
+
+func (q *fakeQueue) UpdateImportExpectedCount(context.Context, uuid.UUID, string, int) error {
+	return nil
+}
+
+func (q *fakeQueue) FailImport(_ context.Context, _ uuid.UUID, _ string, phase ImportPhase, message string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.failures = append(q.failures, ImportFailure{Phase: phase, Message: message})
+
+	return nil
+}
+
+func (q *fakeQueue) UpdateImportProgress(_ context.Context, _ uuid.UUID, _ string, progress ImportProgress) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.progress = append(q.progress, progress)
+
+	return nil
+}
+
+// saved returns every progress persisted so far.
+func (q *fakeQueue) saved() []ImportProgress {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return slices.Clone(q.progress)
+}
+
+// last returns the progress persisted last.
+func (q *fakeQueue) last() ImportProgress {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.progress) == 0 {
+		return ImportProgress{}
+	}
+
+	return q.progress[len(q.progress)-1]
+}
+
+// failed returns every failure recorded so far.
+func (q *fakeQueue) failed() []ImportFailure {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return slices.Clone(q.failures)
+}
+
+// fakeCatalog records the batches upserted into it, or fails with err.
+type fakeCatalog struct {
+	CatalogStore
+
+	err error
+
+	mu      sync.Mutex
+	batches [][]Pokemon
+}
+
+func (c *fakeCatalog) UpsertPokemonBatch(
+	_ context.Context,
+	_ uuid.UUID,
+	pokemon []Pokemon,
+	_ bool,
+) (UpsertCounts, error) {
+	if c.err != nil {
+		return UpsertCounts{}, c.err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.batches = append(c.batches, slices.Clone(pokemon))
+
+	return UpsertCounts{Inserted: len(pokemon)}, nil
+}
+
+// written returns the number of batches upserted so far.
+func (c *fakeCatalog) written() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.batches)
+}
+
+// fakeImportErrors records the species an import skipped, or fails with err.
+type fakeImportErrors struct {
+	ImportErrorStore
+
+	err error
+
+	mu       sync.Mutex
+	recorded []int
+}
+
+func (e *fakeImportErrors) RecordImportError(_ context.Context, _ uuid.UUID, _ string, itemErr ItemError) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.recorded = append(e.recorded, itemErr.PokedexID)
+
+	return nil
+}
+
+func (e *fakeImportErrors) ClearImportErrors(context.Context, uuid.UUID, int) error {
+	return nil
+}
+
+func newPipelineService(catalog CatalogStore, queue ImportQueue, flushInterval time.Duration) *Service {
+	return &Service{
+		catalog:     catalog,
+		queue:       queue,
+		concurrency: 4,
+		worker:      WorkerConfig{FlushInterval: flushInterval},
+		workerID:    "test-worker",
+	}
+}
+
+func fetchSpecies(_ context.Context, pokedexID int) (*Pokemon, error) {
+	return &Pokemon{PokedexID: pokedexID, Name: fmt.Sprintf("pokemon-%d", pokedexID)}, nil
+}
+
+func pokedexRange(from, to int) []int {
+	ids := make([]int, 0, to-from+1)
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func TestCheckpointTracker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		checkpoint    int
+		handled       []int
+		want          int
+		wantRemaining int
+	}{
+		{name: "nothing handled keeps the checkpoint", checkpoint: 3, want: 3, wantRemaining: 4},
+		{name: "handled in order", checkpoint: 3, handled: []int{4, 5}, want: 5, wantRemaining: 2},
+		{name: "a gap holds the checkpoint back", checkpoint: 3, handled: []int{5, 6, 7}, want: 3, wantRemaining: 4},
+		{name: "stops before the first gap", checkpoint: 3, handled: []int{4, 6, 7}, want: 4, wantRemaining: 3},
+		{name: "closing the gap catches up", checkpoint: 3, handled: []int{7, 5, 6, 4}, want: 7, wantRemaining: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: a tracker resumed after the checkpoint
+			tracker := newCheckpointTracker([]int{4, 5, 6, 7}, tt.checkpoint)
+
+			// when: targets are handled one at a time
+			for _, id := range tt.handled {
+				tracker.markHandled(id)
+			}
+
+			// then: the checkpoint never passes a target that is not handled yet
+			testastic.Equal(t, tt.want, tracker.checkpoint())
+			testastic.Equal(t, tt.wantRemaining, tracker.remaining())
+		})
+	}
+}
+
+func TestStreamImportOutOfOrderCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	// given: species 2 finishes fetching only after the batch without it was written
+	queue := &fakeQueue{}
+	catalog := &fakeCatalog{}
+	service := newPipelineService(catalog, queue, 10*time.Millisecond)
+
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, pokedexID int) (*Pokemon, error) {
+		if pokedexID == 2 {
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		return fetchSpecies(ctx, pokedexID)
+	}
+
+	imp := Import{ID: uuid.New(), Mode: ImportModeFull}
+	run := newRunningImport(imp.ID, func(error) {})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- service.streamImport(t.Context(), imp, run, pokedexRange(1, 4), fetch)
+	}()
+
+	// when: species 1, 3 and 4 are written
+	testastic.EventuallyEqual(t, 3, func() int { return queue.last().ItemCount }, testTimeout)
+
+	// then: the checkpoint stays before the gap
+	testastic.Equal(t, 1, queue.last().Checkpoint)
+
+	// when: species 2 arrives
+	close(release)
+
+	// then: the checkpoint catches up with every species
+	testastic.NoError(t, <-done)
+	testastic.Equal(t, ImportProgress{ItemCount: 4, Checkpoint: 4, Upserts: UpsertCounts{Inserted: 4}}, queue.last())
+
+	for _, progress := range queue.saved() {
+		if progress.ItemCount < 4 {
+			testastic.LessOrEqual(t, progress.Checkpoint, 1)
+		}
+	}
+}
+
+func TestBatchWriterFlushesPartialBatchOnInterval(t *testing.T) {
+	t.Parallel()
+
+	// given: a writer whose batch stays far from full
+	queue := &fakeQueue{}
+	catalog := &fakeCatalog{}
+	service := newPipelineService(catalog, queue, 50*time.Millisecond)
+
+	tracker := newCheckpointTracker(pokedexRange(1, 3), 0)
+	writer := &batchWriter{
+		service:  service,
+		importID: uuid.New(),
+		tracker:  tracker,
+		batch:    make([]fetchedPokemon, 0, batchSize),
+	}
+
+	// when: three species arrive while the results channel stays open
+	results := make(chan fetchedPokemon, 3)
+	for id := 1; id <= 3; id++ {
+		results <- fetchedPokemon{targetID: id, pokemon: Pokemon{PokedexID: id}}
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- writer.run(t.Context(), results)
+	}()
+
+	// then: the flush interval writes them without waiting for a full batch
+	testastic.EventuallyEqual(t, 3, func() int { return queue.last().ItemCount }, testTimeout)
+	testastic.Equal(t, 3, queue.last().Checkpoint)
+	testastic.Equal(t, 1, catalog.written())
+
+	close(results)
+	testastic.NoError(t, <-done)
+}
+
+func TestStreamImportReportsProgressWhileRunning(t *testing.T) {
+	t.Parallel()
+
+	// given: an import whose last species hangs until released, and no flush interval in reach
+	queue := &fakeQueue{}
+	catalog := &fakeCatalog{}
+	service := newPipelineService(catalog, queue, time.Hour)
+
+	ids := pokedexRange(1, batchSize+10)
+	last := ids[len(ids)-1]
+
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, pokedexID int) (*Pokemon, error) {
+		if pokedexID == last {
+			<-release
+		}
+
+		return fetchSpecies(ctx, pokedexID)
+	}
+
+	imp := Import{ID: uuid.New(), Mode: ImportModeFull}
+	run := newRunningImport(imp.ID, func(error) {})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- service.streamImport(t.Context(), imp, run, ids, fetch)
+	}()
+
+	// when / then: the first full batch is persisted while the import still runs
+	testastic.EventuallyEqual(t, batchSize, func() int { return queue.last().ItemCount }, testTimeout)
+
+	select {
+	case err := <-done:
+		t.Fatalf("import finished before its last species was fetched: %v", err)
+	default:
+	}
+
+	// when: the last species is released
+	close(release)
+
+	// then: the rest is written once the import finishes
+	testastic.NoError(t, <-done)
+	testastic.Equal(t, len(ids), queue.last().ItemCount)
+	testastic.Equal(t, last, queue.last().Checkpoint)
+}
+
+func TestStreamImportWriterErrorCancelsFetchers(t *testing.T) {
+	t.Parallel()
+
+	// given: a failing catalog, and fetchers past the first batch that only return once cancelled
+	queue := &fakeQueue{}
+	catalog := &fakeCatalog{err: errCatalogDown}
+	service := newPipelineService(catalog, queue, time.Hour)
+
+	fetch := func(ctx context.Context, pokedexID int) (*Pokemon, error) {
+		if pokedexID > batchSize {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}
+
+		return fetchSpecies(ctx, pokedexID)
+	}
+
+	imp := Import{ID: uuid.New(), Mode: ImportModeFull}
+	run := newRunningImport(imp.ID, func(error) {})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- service.streamImport(t.Context(), imp, run, pokedexRange(1, 2*batchSize), fetch)
+	}()
+
+	// when: the first batch fails to write
+	var err error
+
+	select {
+	case err = <-done:
+	case <-time.After(testTimeout):
+		t.Fatal("fetchers were not cancelled after the writer failed")
+	}
+
+	// then: the import fails in the upsert phase without persisting progress
+	testastic.ErrorIs(t, err, errCatalogDown)
+
+	var phaseErr *phaseError
+
+	testastic.True(t, errors.As(err, &phaseErr))
+	testastic.Equal(t, ImportPhaseUpsert, phaseErr.phase)
+	testastic.Empty(t, queue.saved())
+}
+
+func TestStreamImportSkippedSpecies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		recordErr      error
+		wantErr        error
+		wantCheckpoint int
+	}{
+		{name: "stored error moves the checkpoint past the species", wantCheckpoint: 3},
+		{
+			name:      "unstored error fails the import",
+			recordErr: errCatalogDown,
+			wantErr:   errCatalogDown,
+		},
+		{
+			name:      "lost lease fails the import",
+			recordErr: ErrImportLeaseLost,
+			wantErr:   ErrImportLeaseLost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: species 2 cannot be fetched
+			queue := &fakeQueue{}
+			service := newPipelineService(&fakeCatalog{}, queue, time.Hour)
+			importErrors := &fakeImportErrors{err: tt.recordErr}
+			service.importErrors = importErrors
+
+			fetch := func(ctx context.Context, pokedexID int) (*Pokemon, error) {
+				if pokedexID == 2 {
+					return nil, errNotFound
+				}
+
+				return fetchSpecies(ctx, pokedexID)
+			}
+
+			imp := Import{ID: uuid.New(), Mode: ImportModeFull}
+			run := newRunningImport(imp.ID, func(error) {})
+
+			// when: the import runs
+			err := service.streamImport(t.Context(), imp, run, pokedexRange(1, 3), fetch)
+
+			// then: the checkpoint only passes species 2 once its error is stored
+			if tt.wantErr != nil {
+				testastic.ErrorIs(t, err, tt.wantErr)
+
+				for _, progress := range queue.saved() {
+					testastic.Less(t, progress.Checkpoint, 2)
+				}
+
+				return
+			}
+
+			testastic.NoError(t, err)
+			testastic.SliceEqual(t, []int{2}, importErrors.recorded)
+			testastic.Equal(t, tt.wantCheckpoint, queue.last().Checkpoint)
+		})
+	}
+}
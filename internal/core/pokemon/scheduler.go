@@ -0,0 +1,146 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Scheduler creates imports on the ticks of their schedules.
+//
+// Every replica runs a scheduler, but only one of them fires each tick. A tick
+// is skipped while another import is still queued or processing.
+type Scheduler struct {
+	service *Service
+	store   ScheduleStore
+	jobs    []ScheduledImport
+	stop    context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler creating the given imports through the service.
+func NewScheduler(service *Service, store ScheduleStore, jobs []ScheduledImport) *Scheduler {
+	return &Scheduler{
+		service: service,
+		store:   store,
+		jobs:    jobs,
+	}
+}
+
+// Start launches one goroutine per scheduled import until Shutdown is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.stop = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		s.wg.Go(func() {
+			s.run(ctx, job)
+		})
+	}
+}
+
+// Shutdown stops the scheduler and waits for in-flight ticks to finish.
+func (s *Scheduler) Shutdown() {
+	if s.stop != nil {
+		s.stop()
+	}
+
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, job ScheduledImport) {
+	slog.InfoContext(ctx, "import schedule started", slog.String("schedule", job.Name))
+
+	for {
+		tick := job.Schedule.Next(time.Now())
+		if tick.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(tick))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		s.fire(ctx, job, tick)
+	}
+}
+
+func (s *Scheduler) fire(ctx context.Context, job ScheduledImport, tick time.Time) {
+	logger := slog.With(slog.String("schedule", job.Name), slog.Time("tick", tick))
+
+	var created *Import
+
+	fired, err := s.store.FireSchedule(ctx, job.Name, tick, func(ctx context.Context, imports ImportStore) error {
+		busy, err := importInFlight(ctx, imports)
+		if err != nil {
+			return err
+		}
+
+		if busy {
+			logger.InfoContext(ctx, "skipping scheduled import, another import is still running")
+
+			return nil
+		}
+
+		imp, err := s.service.newImport(job.Params)
+		if err != nil {
+			return fmt.Errorf("creating scheduled import: %w", err)
+		}
+
+		created, err = s.service.insertImport(ctx, imports, imp, nil)
+		if errors.Is(err, ErrImportInFlight) {
+			logger.InfoContext(ctx, "skipping scheduled import, another import is still running")
+
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("creating scheduled import: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.ErrorContext(ctx, "failed to fire import schedule", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	if !fired {
+		logger.DebugContext(ctx, "import schedule tick fired by another replica")
+
+		return
+	}
+
+	// The import is only visible to the worker once the tick is committed.
+	if created != nil {
+		logger.InfoContext(ctx, "scheduled import created", slog.String("import_id", created.ID.String()))
+		s.service.notifyWorker()
+	}
+}
+
+// importInFlight reports whether any import is queued, processing or interrupted.
+func importInFlight(ctx context.Context, imports ImportStore) (bool, error) {
+	for _, status := range []ImportStatus{ImportStatusPending, ImportStatusProcessing, ImportStatusInterrupted} {
+		count, err := imports.CountImports(ctx, ImportFilter{Status: &status})
+		if err != nil {
+			return false, fmt.Errorf("counting %s imports: %w", status, err)
+		}
+
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -2,63 +2,172 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/sync/errgroup"
 )
 
 // Service orchestrates Pokemon imports and catalog queries.
 type Service struct {
-	fetcher     Fetcher
-	imports     ImportStore
-	catalog     CatalogStore
-	concurrency int
-	cancelFunc  context.CancelFunc
+	sources      Sources
+	imports      ImportStore
+	queue        ImportQueue
+	stale        StaleImportStore
+	importErrors ImportErrorStore
+	uploads      ImportUploadStore
+	diffs        ImportDiffStore
+	catalog      CatalogStore
+	history      PokemonHistoryStore
+	concurrency  int
+	worker       WorkerConfig
+	workerID     string
+	wake         chan struct{}
+	stop         context.CancelFunc
+	wg           sync.WaitGroup
+	supervisor   *importSupervisor
+}
+
+// WorkerConfig controls how the import worker claims and holds queued imports.
+type WorkerConfig struct {
+	PollInterval      time.Duration
+	LeaseDuration     time.Duration
+	HeartbeatInterval time.Duration
+	// FlushInterval bounds how long fetched Pokemon wait before being written.
+	FlushInterval time.Duration
+	// DrainTimeout bounds how long Shutdown waits for running imports to write
+	// their current batch.
+	DrainTimeout time.Duration
+	// StaleAfter is how long a processing import whose lease expired goes
+	// without an update before the sweeper recovers it.
+	StaleAfter time.Duration
+	// SweepInterval is how often the sweeper looks for stale imports.
+	SweepInterval time.Duration
+	// MaxResumes is how often an import is resumed after its worker died
+	// before it is failed.
+	MaxResumes int
+}
+
+// Stores holds the stores the Pokemon service persists imports and the catalog in.
+type Stores struct {
+	Imports      ImportStore
+	Queue        ImportQueue
+	Stale        StaleImportStore
+	ImportErrors ImportErrorStore
+	Uploads      ImportUploadStore
+	Diffs        ImportDiffStore
+	Catalog      CatalogStore
+	History      PokemonHistoryStore
 }
 
 // NewService creates a new Pokemon service.
-func NewService(fetcher Fetcher, imports ImportStore, catalog CatalogStore, concurrency int) *Service {
+func NewService(sources Sources, stores Stores, concurrency int, worker WorkerConfig) *Service {
 	return &Service{
-		fetcher:     fetcher,
-		imports:     imports,
-		catalog:     catalog,
-		concurrency: concurrency,
+		sources:      sources,
+		imports:      stores.Imports,
+		queue:        stores.Queue,
+		stale:        stores.Stale,
+		importErrors: stores.ImportErrors,
+		uploads:      stores.Uploads,
+		diffs:        stores.Diffs,
+		catalog:      stores.Catalog,
+		history:      stores.History,
+		concurrency:  concurrency,
+		worker:       worker,
+		workerID:     uuid.NewString(),
+		wake:         make(chan struct{}, 1),
+		supervisor:   newImportSupervisor(),
 	}
 }
 
-// CreateImport creates a new import record and starts async processing.
-func (s *Service) CreateImport(ctx context.Context, source string) (*Import, error) {
-	now := time.Now()
+// CreateImport queues a new import of the targeted species for the worker to pick up.
+//
+// The source must be registered with the service unless it is SourceFile. A
+// file import stores its upload along with the import and targets every
+// uploaded species. While an import of the same source is pending or
+// processing, it returns an ImportInFlightError.
+func (s *Service) CreateImport(ctx context.Context, params CreateImportParams) (*Import, error) {
+	imp, err := s.newImport(params)
+	if err != nil {
+		return nil, err
+	}
 
-	id, err := uuid.NewV7()
+	return s.queueImport(ctx, imp, params.Upload)
+}
+
+// newImport builds the import that params describe.
+func (s *Service) newImport(params CreateImportParams) (Import, error) {
+	if _, ok := s.sources[params.Source]; !ok && params.Source != SourceFile {
+		return Import{}, fmt.Errorf("%w %q", ErrUnknownSource, params.Source)
+	}
+
+	mode := params.Mode
+	if mode == "" {
+		mode = ImportModeFull
+	}
+
+	imp := Import{Source: params.Source, Mode: mode, Targets: params.Targets}
+
+	if params.Source == SourceFile {
+		if len(params.Upload) == 0 {
+			return Import{}, ErrUploadRequired
+		}
+
+		imp.Targets = ImportTargets{PokedexIDs: make([]int, 0, len(params.Upload))}
+		for _, p := range params.Upload {
+			imp.Targets.PokedexIDs = append(imp.Targets.PokedexIDs, p.PokedexID)
+		}
+	}
+
+	if imp.Targets.Empty() {
+		imp.Targets = AllSpecies()
+	}
+
+	return imp, nil
+}
+
+// RetryImport queues a child import that fetches only the species the given
+// import failed on or never reached.
+func (s *Service) RetryImport(ctx context.Context, id uuid.UUID) (*Import, error) {
+	parent, err := s.imports.GetImport(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("creating import id: %w", err)
+		return nil, fmt.Errorf("getting import: %w", err)
 	}
 
-	imp := Import{
-		ID:        id,
-		Source:    source,
-		Status:    ImportStatusPending,
-		ItemCount: 0,
-		CreatedAt: now,
-		UpdatedAt: now,
+	if parent.Status == ImportStatusPending || parent.Status == ImportStatusProcessing ||
+		parent.Status == ImportStatusInterrupted {
+		return nil, ErrImportRunning
 	}
 
-	err = s.imports.CreateImport(ctx, imp)
+	failedIDs, err := s.importErrors.ListImportErrorIDs(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("creating import record: %w", err)
+		return nil, fmt.Errorf("listing failed pokedex ids: %w", err)
 	}
 
-	bgCtx, cancel := context.WithCancel(context.Background())
-	s.cancelFunc = cancel
+	targets := parent.RetryTargets(failedIDs)
+	if targets.Empty() {
+		return nil, ErrNothingToRetry
+	}
 
-	//nolint:contextcheck // Detached from request context so the import outlives the HTTP request.
-	go s.runImport(bgCtx, id)
+	var upload []Pokemon
 
-	return &imp, nil
+	// A retried file import carries over the uploaded rows it targets.
+	if parent.Source == SourceFile {
+		upload, err = s.uploads.ListUploadedPokemon(ctx, parent.ID, targets.PokedexIDs)
+		if err != nil {
+			return nil, fmt.Errorf("listing uploaded pokemon: %w", err)
+		}
+	}
+
+	return s.queueImport(ctx, Import{
+		Source:   parent.Source,
+		Mode:     parent.Mode,
+		ParentID: &parent.ID,
+		Targets:  targets,
+	}, upload)
 }
 
 // GetImport returns the current state of an import.
@@ -71,6 +180,105 @@ func (s *Service) GetImport(ctx context.Context, id uuid.UUID) (*Import, error)
 	return &imp, nil
 }
 
+// CancelImport cancels a pending or processing import.
+//
+// Batches that were already persisted stay in the catalog. The worker running
+// the import stops its in-flight fetches, immediately when it runs in this
+// process and on its next lease renewal otherwise.
+func (s *Service) CancelImport(ctx context.Context, id uuid.UUID) (*Import, error) {
+	imp, err := s.imports.CancelImport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cancelling import: %w", err)
+	}
+
+	run, ok := s.supervisor.get(id)
+	if ok {
+		run.cancel(ErrImportCancelled)
+	}
+
+	return &imp, nil
+}
+
+// WatchImport streams a snapshot of an import's progress whenever it changed,
+// checking every flush interval. The channel is closed once the import
+// finished or ctx is done.
+func (s *Service) WatchImport(ctx context.Context, id uuid.UUID) (<-chan ImportEvent, error) {
+	imp, err := s.imports.GetImport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting import: %w", err)
+	}
+
+	events := make(chan ImportEvent)
+
+	go func() {
+		defer close(events)
+
+		s.watchImport(ctx, imp, events)
+	}()
+
+	return events, nil
+}
+
+// ListImportErrors returns the species an import skipped and the total number of them.
+func (s *Service) ListImportErrors(ctx context.Context, id uuid.UUID, limit, offset int) ([]ItemError, int64, error) {
+	imp, err := s.imports.GetImport(ctx, id)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting import: %w", err)
+	}
+
+	items, err := s.importErrors.ListImportErrors(ctx, id, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing import errors: %w", err)
+	}
+
+	return items, int64(imp.FailedCount), nil
+}
+
+// GetImportDiff returns the species a dry-run import found to differ from the
+// catalog, optionally of one kind, together with the counts of every kind.
+func (s *Service) GetImportDiff(
+	ctx context.Context,
+	id uuid.UUID,
+	kind *DiffKind,
+	limit, offset int,
+) ([]SpeciesDiff, DiffSummary, error) {
+	imp, err := s.imports.GetImport(ctx, id)
+	if err != nil {
+		return nil, DiffSummary{}, fmt.Errorf("getting import: %w", err)
+	}
+
+	if imp.Mode != ImportModeDryRun {
+		return nil, DiffSummary{}, ErrNotDryRun
+	}
+
+	items, err := s.diffs.ListImportDiffs(ctx, id, kind, limit, offset)
+	if err != nil {
+		return nil, DiffSummary{}, fmt.Errorf("listing import diffs: %w", err)
+	}
+
+	summary, err := s.diffs.SummarizeImportDiffs(ctx, id)
+	if err != nil {
+		return nil, DiffSummary{}, fmt.Errorf("summarizing import diffs: %w", err)
+	}
+
+	return items, summary, nil
+}
+
+// ListImports returns imports, newest first, and the matching total count.
+func (s *Service) ListImports(ctx context.Context, params ImportListParams) ([]Import, int64, error) {
+	items, err := s.imports.ListImports(ctx, params)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing imports: %w", err)
+	}
+
+	total, err := s.imports.CountImports(ctx, params.Filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting imports: %w", err)
+	}
+
+	return items, total, nil
+}
+
 // GetPokemonByID returns a Pokemon by Pokedex ID.
 func (s *Service) GetPokemonByID(ctx context.Context, pokedexID int) (*Pokemon, error) {
 	p, err := s.catalog.GetPokemonByID(ctx, pokedexID)
@@ -81,6 +289,27 @@ func (s *Service) GetPokemonByID(ctx context.Context, pokedexID int) (*Pokemon,
 	return &p, nil
 }
 
+// GetPokemonHistory returns the changes imports made to a catalog species,
+// newest first, and their total count.
+func (s *Service) GetPokemonHistory(ctx context.Context, pokedexID, limit, offset int) ([]PokemonChange, int64, error) {
+	_, err := s.catalog.GetPokemonByID(ctx, pokedexID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting pokemon: %w", err)
+	}
+
+	changes, err := s.history.ListPokemonHistory(ctx, pokedexID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing pokemon history: %w", err)
+	}
+
+	total, err := s.history.CountPokemonHistory(ctx, pokedexID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting pokemon history: %w", err)
+	}
+
+	return changes, total, nil
+}
+
 // ListPokemon returns Pokemon and the matching total count.
 func (s *Service) ListPokemon(ctx context.Context, params ListParams) ([]Pokemon, int64, error) {
 	items, err := s.catalog.ListPokemon(ctx, params)
@@ -88,7 +317,7 @@ func (s *Service) ListPokemon(ctx context.Context, params ListParams) ([]Pokemon
 		return nil, 0, fmt.Errorf("listing pokemon: %w", err)
 	}
 
-	total, err := s.catalog.CountPokemon(ctx, params.Rarity)
+	total, err := s.catalog.CountPokemon(ctx, params.Rarity, params.IncludeInactive)
 	if err != nil {
 		return nil, 0, fmt.Errorf("counting pokemon: %w", err)
 	}
@@ -96,133 +325,193 @@ func (s *Service) ListPokemon(ctx context.Context, params ListParams) ([]Pokemon
 	return items, total, nil
 }
 
-// Shutdown cancels any running imports.
+// Start launches the import worker, which claims queued imports until Shutdown
+// is called, and the sweeper, which recovers stale imports right away and then
+// every sweep interval.
+func (s *Service) Start(ctx context.Context) {
+	ctx, s.stop = context.WithCancel(ctx)
+
+	s.wg.Go(func() {
+		s.work(ctx)
+	})
+
+	s.wg.Go(func() {
+		s.sweep(ctx)
+	})
+}
+
+// Shutdown stops the import worker and waits for it to return.
+//
+// The worker stops claiming imports and a running import stops fetching new
+// species. Shutdown waits up to the drain timeout for it to write the species
+// it already fetched, and cancels it once the timeout passed. Either way the
+// import is marked interrupted and released, so the next worker resumes it
+// from its checkpoint right away.
 func (s *Service) Shutdown() {
-	if s.cancelFunc != nil {
-		s.cancelFunc()
+	if s.stop != nil {
+		s.stop()
 	}
-}
 
-const batchSize = 50
+	s.supervisor.drain()
 
-func (s *Service) runImport(ctx context.Context, importID uuid.UUID) {
-	idStr := importID.String()
-	slog.InfoContext(ctx, "starting import", slog.String("import_id", idStr))
+	done := make(chan struct{})
 
-	err := s.imports.UpdateImportStatus(ctx, importID, ImportStatusProcessing, 0)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to update import status to processing", slog.Any("error", err))
+	go func() {
+		defer close(done)
 
-		return
-	}
+		s.wg.Wait()
+	}()
 
-	count, err := s.fetcher.FetchSpeciesCount(ctx)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to fetch species count", slog.Any("error", err))
-		s.failImport(ctx, importID)
+	timer := time.NewTimer(s.worker.DrainTimeout)
+	defer timer.Stop()
 
+	select {
+	case <-done:
 		return
+	case <-timer.C:
 	}
 
-	slog.InfoContext(ctx, "fetching pokemon", slog.Int("total", count))
+	slog.WarnContext(context.Background(), "import drain timed out, interrupting running imports",
+		slog.Duration("drain_timeout", s.worker.DrainTimeout),
+	)
+	s.supervisor.interrupt()
 
-	pokemon, err := s.fetchAll(ctx, count)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to fetch pokemon", slog.Any("error", err))
-		s.failImport(ctx, importID)
+	<-done
+}
 
-		return
+// queueImport stores imp as a new pending import and wakes up the worker.
+func (s *Service) queueImport(ctx context.Context, imp Import, upload []Pokemon) (*Import, error) {
+	queued, err := s.insertImport(ctx, s.imports, imp, upload)
+	if err != nil {
+		return nil, err
 	}
 
-	if !s.upsertAllBatches(ctx, importID, pokemon) {
-		return
-	}
+	s.notifyWorker()
 
-	s.completeImport(ctx, importID, idStr, len(pokemon))
+	return queued, nil
 }
 
-func (s *Service) upsertAllBatches(ctx context.Context, importID uuid.UUID, pokemon []Pokemon) bool {
-	for i := 0; i < len(pokemon); i += batchSize {
-		end := min(i+batchSize, len(pokemon))
-		batch := pokemon[i:end]
+// insertImport stores imp as a new pending import through imports, which may
+// run on the caller's transaction. A file import is stored along with its
+// upload outside of it.
+func (s *Service) insertImport(
+	ctx context.Context,
+	imports ImportStore,
+	imp Import,
+	upload []Pokemon,
+) (*Import, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating import id: %w", err)
+	}
 
-		err := s.catalog.UpsertPokemonBatch(ctx, batch)
-		if err != nil {
-			slog.ErrorContext(ctx, "failed to upsert batch", slog.Any("error", err))
-			s.failImport(ctx, importID)
+	now := time.Now()
 
-			return false
-		}
+	imp.ID = id
+	imp.Status = ImportStatusPending
+	imp.CreatedAt = now
+	imp.UpdatedAt = now
 
-		updateErr := s.imports.UpdateImportStatus(ctx, importID, ImportStatusProcessing, end)
-		if updateErr != nil {
-			slog.ErrorContext(ctx, "failed to update item count", slog.Any("error", updateErr))
-		}
+	if imp.Source == SourceFile {
+		err = s.uploads.CreateImportWithUpload(ctx, imp, upload)
+	} else {
+		err = imports.CreateImport(ctx, imp)
 	}
 
-	return true
+	if errors.Is(err, ErrImportInFlight) {
+		return nil, s.inFlightError(ctx, imp.Source)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("creating import record: %w", err)
+	}
+
+	return &imp, nil
 }
 
-func (s *Service) completeImport(ctx context.Context, importID uuid.UUID, idStr string, count int) {
-	err := s.imports.UpdateImportStatus(ctx, importID, ImportStatusCompleted, count)
+// inFlightError describes the import of the given source that blocked a new
+// one. It is only ErrImportInFlight when that import finished in the meantime.
+func (s *Service) inFlightError(ctx context.Context, source string) error {
+	active, err := s.imports.GetActiveImport(ctx, source)
+	if errors.Is(err, ErrImportNotFound) {
+		return ErrImportInFlight
+	}
+
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to update import status to completed", slog.Any("error", err))
+		return fmt.Errorf("getting in-flight import: %w", err)
 	}
 
-	slog.InfoContext(ctx, "import completed",
-		slog.String("import_id", idStr),
-		slog.Int("count", count),
-	)
+	return &ImportInFlightError{Active: active}
 }
 
-func (s *Service) fetchAll(ctx context.Context, count int) ([]Pokemon, error) {
-	g, gCtx := errgroup.WithContext(ctx)
-	g.SetLimit(s.concurrency)
+func (s *Service) watchImport(ctx context.Context, imp Import, events chan<- ImportEvent) {
+	ticker := time.NewTicker(s.worker.FlushInterval)
+	defer ticker.Stop()
 
-	results := make(chan Pokemon, count)
+	var last ImportEvent
 
-	for id := 1; id <= count; id++ {
-		pokemonID := id
+	for {
+		event := s.importEvent(imp)
+		if event != last {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
 
-		g.Go(func() error {
-			p, err := s.fetcher.FetchPokemon(gCtx, pokemonID)
-			if err != nil {
-				slog.WarnContext(gCtx, "skipping pokemon",
-					slog.Int("id", pokemonID),
+			last = event
+		}
+
+		if imp.Status.Finished() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := s.imports.GetImport(ctx, imp.ID)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "failed to get watched import",
+					slog.String("import_id", imp.ID.String()),
 					slog.Any("error", err),
 				)
-
-				return nil
 			}
 
-			results <- *p
+			return
+		}
 
-			return nil
-		})
+		imp = current
 	}
+}
 
-	go func() {
-		_ = g.Wait()
-
-		close(results)
-	}()
-
-	var pokemon []Pokemon
-	for p := range results {
-		pokemon = append(pokemon, p)
+// importEvent snapshots the persisted progress of an import together with the
+// live progress while it runs on this worker.
+func (s *Service) importEvent(imp Import) ImportEvent {
+	event := ImportEvent{
+		ImportID:  imp.ID,
+		Status:    imp.Status,
+		Fetched:   imp.ItemCount,
+		Persisted: imp.ItemCount,
+		Failed:    imp.FailedCount,
+		Expected:  imp.ExpectedCount,
 	}
 
-	err := g.Wait()
-	if err != nil {
-		return nil, fmt.Errorf("fetching pokemon: %w", err)
+	run, ok := s.supervisor.get(imp.ID)
+	if ok && !imp.Status.Finished() {
+		event.Fetched = max(event.Persisted, int(run.fetched.Load()))
+		event.Expected = int(run.expected.Load())
 	}
 
-	return pokemon, nil
+	return event
 }
 
-func (s *Service) failImport(ctx context.Context, importID uuid.UUID) {
-	err := s.imports.UpdateImportStatus(ctx, importID, ImportStatusFailed, 0)
-	if err != nil {
-		slog.ErrorContext(ctx, "failed to update import status to failed", slog.Any("error", err))
+func (s *Service) notifyWorker() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
 	}
 }
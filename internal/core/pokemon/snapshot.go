@@ -0,0 +1,73 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// snapshotPageSize is the number of Pokemon read per page while exporting.
+const snapshotPageSize = 500
+
+// Snapshot is a copy of the catalog for seeding other environments.
+type Snapshot struct {
+	CreatedAt time.Time
+	Pokemon   []Pokemon
+}
+
+// Snapshots exports the catalog to snapshots and seeds empty catalogs from them.
+type Snapshots struct {
+	catalog CatalogStore
+	seeder  CatalogSeeder
+}
+
+// NewSnapshots creates a snapshot service for the catalog.
+func NewSnapshots(catalog CatalogStore, seeder CatalogSeeder) *Snapshots {
+	return &Snapshots{catalog: catalog, seeder: seeder}
+}
+
+// Export returns a snapshot of every active Pokemon in the catalog, ordered by
+// Pokedex ID.
+func (s *Snapshots) Export(ctx context.Context) (Snapshot, error) {
+	snapshot := Snapshot{CreatedAt: time.Now().UTC()}
+
+	for offset := 0; ; offset += snapshotPageSize {
+		page, err := s.catalog.ListPokemon(ctx, ListParams{Limit: snapshotPageSize, Offset: offset})
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("listing pokemon: %w", err)
+		}
+
+		snapshot.Pokemon = append(snapshot.Pokemon, page...)
+
+		if len(page) < snapshotPageSize {
+			return snapshot, nil
+		}
+	}
+}
+
+// Load seeds an empty catalog from the snapshot. It returns ErrCatalogNotEmpty
+// when the catalog already holds Pokemon and ErrInvalidPokemon when the
+// snapshot holds an invalid one or the same Pokedex ID twice.
+func (s *Snapshots) Load(ctx context.Context, snapshot Snapshot) error {
+	seen := make(map[int]struct{}, len(snapshot.Pokemon))
+
+	for _, p := range snapshot.Pokemon {
+		err := p.Validate()
+		if err != nil {
+			return fmt.Errorf("pokemon %d: %w", p.PokedexID, err)
+		}
+
+		if _, ok := seen[p.PokedexID]; ok {
+			return fmt.Errorf("pokemon %d: %w: duplicate pokedex_id", p.PokedexID, ErrInvalidPokemon)
+		}
+
+		seen[p.PokedexID] = struct{}{}
+	}
+
+	err := s.seeder.SeedCatalog(ctx, snapshot.Pokemon)
+	if err != nil {
+		return fmt.Errorf("seeding catalog: %w", err)
+	}
+
+	return nil
+}
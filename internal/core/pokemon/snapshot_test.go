@@ -0,0 +1,74 @@
+package pokemon_test
+
+import (
+	"context"
+	"reference-service-go/internal/core/pokemon"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+// fakeSeeder records the Pokemon it was asked to seed.
+type fakeSeeder struct {
+	seeded []pokemon.Pokemon
+}
+
+func (s *fakeSeeder) SeedCatalog(_ context.Context, batch []pokemon.Pokemon) error {
+	s.seeded = append(s.seeded, batch...)
+
+	return nil
+}
+
+func TestSnapshotsLoad(t *testing.T) {
+	t.Parallel()
+
+	valid := func(id int) pokemon.Pokemon {
+		return pokemon.Pokemon{PokedexID: id, Name: "bulbasaur", Types: []string{"grass"}, Rarity: pokemon.RarityCommon}
+	}
+
+	tests := []struct {
+		name    string
+		pokemon []pokemon.Pokemon
+		wantErr string
+	}{
+		{
+			name:    "seeds valid pokemon",
+			pokemon: []pokemon.Pokemon{valid(1), valid(2)},
+		},
+		{
+			name:    "rejects an invalid pokemon",
+			pokemon: []pokemon.Pokemon{valid(1), {PokedexID: 2}},
+			wantErr: "pokemon 2: invalid pokemon: name is required",
+		},
+		{
+			name:    "rejects a repeated pokedex id",
+			pokemon: []pokemon.Pokemon{valid(1), valid(2), valid(1)},
+			wantErr: "pokemon 1: invalid pokemon: duplicate pokedex_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: a snapshot service seeding an empty catalog
+			seeder := &fakeSeeder{}
+			snapshots := pokemon.NewSnapshots(nil, seeder)
+
+			// when: a snapshot is loaded
+			err := snapshots.Load(t.Context(), pokemon.Snapshot{Pokemon: tt.pokemon})
+
+			// then: only a valid snapshot reaches the catalog
+			if tt.wantErr == "" {
+				testastic.NoError(t, err)
+				testastic.Len(t, seeder.seeded, len(tt.pokemon))
+
+				return
+			}
+
+			testastic.ErrorIs(t, err, pokemon.ErrInvalidPokemon)
+			testastic.Equal(t, tt.wantErr, err.Error())
+			testastic.Empty(t, seeder.seeded)
+		})
+	}
+}
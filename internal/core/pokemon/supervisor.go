@@ -0,0 +1,114 @@
+package pokemon
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// runningImport is an import running on this worker.
+type runningImport struct {
+	id     uuid.UUID
+	cancel context.CancelCauseFunc
+	// fetched counts fetched species, starting from the persisted count of a resumed import.
+	fetched atomic.Int64
+	// expected is the number of species the import targets in total.
+	expected atomic.Int64
+
+	drainOnce sync.Once
+	// drained is closed once the import should stop fetching new species.
+	drained chan struct{}
+}
+
+func newRunningImport(id uuid.UUID, cancel context.CancelCauseFunc) *runningImport {
+	return &runningImport{id: id, cancel: cancel, drained: make(chan struct{})}
+}
+
+// drain asks the import to stop fetching new species. The species already
+// fetched are still written, so the import stops after its current batch.
+func (r *runningImport) drain() {
+	r.drainOnce.Do(func() { close(r.drained) })
+}
+
+// draining reports whether the import was asked to stop.
+func (r *runningImport) draining() bool {
+	select {
+	case <-r.drained:
+		return true
+	default:
+		return false
+	}
+}
+
+// importSupervisor tracks every import running on this worker, so it can be
+// cancelled, watched and stopped on shutdown.
+type importSupervisor struct {
+	mu      sync.Mutex
+	running map[uuid.UUID]*runningImport
+	// draining and interrupted stop imports that start after shutdown began.
+	draining    bool
+	interrupted bool
+}
+
+func newImportSupervisor() *importSupervisor {
+	return &importSupervisor{running: make(map[uuid.UUID]*runningImport)}
+}
+
+// track registers a running import until the returned func is called.
+func (s *importSupervisor) track(run *runningImport) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.running[run.id] = run
+
+	if s.draining {
+		run.drain()
+	}
+
+	if s.interrupted {
+		run.cancel(ErrImportInterrupted)
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.running, run.id)
+	}
+}
+
+// get returns the import with the given ID if it runs on this worker.
+func (s *importSupervisor) get(id uuid.UUID) (*runningImport, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, ok := s.running[id]
+
+	return run, ok
+}
+
+// drain asks every running import to stop after its current batch.
+func (s *importSupervisor) drain() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.draining = true
+
+	for _, run := range s.running {
+		run.drain()
+	}
+}
+
+// interrupt cancels every running import with ErrImportInterrupted.
+func (s *importSupervisor) interrupt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.interrupted = true
+
+	for _, run := range s.running {
+		run.cancel(ErrImportInterrupted)
+	}
+}
@@ -0,0 +1,94 @@
+package pokemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// sweep recovers stale imports until ctx is done.
+func (s *Service) sweep(ctx context.Context) {
+	ticker := time.NewTicker(s.worker.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		s.recoverStaleImports(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverStaleImports resumes every stale import from its checkpoint, unless
+// it was resumed MaxResumes times already and keeps losing its worker. Such an
+// import is failed with a reason instead.
+func (s *Service) recoverStaleImports(ctx context.Context) {
+	imports, err := s.stale.ListStaleImports(ctx, time.Now().Add(-s.worker.StaleAfter))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to list stale imports", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	resumed := false
+
+	for _, imp := range imports {
+		if imp.ResumeCount >= s.worker.MaxResumes {
+			s.failStaleImport(ctx, imp)
+
+			continue
+		}
+
+		ok, err := s.stale.ResumeStaleImport(ctx, imp)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to resume stale import",
+				slog.String("import_id", imp.ID.String()),
+				slog.Any("error", err),
+			)
+
+			continue
+		}
+
+		if ok {
+			slog.WarnContext(ctx, "resuming stale import",
+				slog.String("import_id", imp.ID.String()),
+				slog.Time("updated_at", imp.UpdatedAt),
+				slog.Int("checkpoint", imp.Checkpoint),
+			)
+
+			resumed = true
+		}
+	}
+
+	if resumed {
+		s.notifyWorker()
+	}
+}
+
+func (s *Service) failStaleImport(ctx context.Context, imp Import) {
+	message := fmt.Sprintf("abandoned after %d resumes: no worker renewed the lease since %s",
+		imp.ResumeCount, imp.UpdatedAt.UTC().Format(time.RFC3339))
+
+	ok, err := s.stale.FailStaleImport(ctx, imp, message)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to mark stale import as failed",
+			slog.String("import_id", imp.ID.String()),
+			slog.Any("error", err),
+		)
+
+		return
+	}
+
+	if ok {
+		slog.WarnContext(ctx, "stale import marked as failed",
+			slog.String("import_id", imp.ID.String()),
+			slog.String("message", message),
+		)
+	}
+}
@@ -2,7 +2,13 @@
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +17,33 @@
 var (
 	ErrImportNotFound  = errors.New("import not found")
 	ErrPokemonNotFound = errors.New("pokemon not found")
+	ErrNoImportQueued  = errors.New("no import queued")
+	ErrImportLeaseLost = errors.New("import lease lost")
+	ErrImportCancelled = errors.New("import cancelled")
+	// ErrImportInterrupted stops an import that is resumed later, such as one
+	// still running when its worker shuts down.
+	ErrImportInterrupted = errors.New("import interrupted")
+	ErrImportFinished    = errors.New("import already finished")
+	ErrImportRunning     = errors.New("import still running")
+	ErrNothingToRetry    = errors.New("import has nothing to retry")
+	ErrInvalidPokemon    = errors.New("invalid pokemon")
+	ErrUploadRequired    = errors.New("file imports require uploaded pokemon")
+	ErrUnknownSource     = errors.New("unknown import source")
+	ErrImportInFlight    = errors.New("import of the same source in flight")
+	ErrNotDryRun         = errors.New("import is not a dry run")
+	// ErrSourceUnavailable fails an import instead of skipping the species
+	// whose fetch returned it.
+	ErrSourceUnavailable = errors.New("import source unavailable")
+	ErrCatalogNotEmpty   = errors.New("catalog is not empty")
+)
+
+// Import sources.
+const (
+	// SourcePokeAPI is the name of the default source fetching species from PokeAPI.
+	SourcePokeAPI = "pokeapi"
+	// SourceFile imports species uploaded with the import request. It is built
+	// in and cannot be registered as a Sources entry.
+	SourceFile = "file"
 )
 
 // Rarity represents the rarity tier of a Pokemon.
@@ -24,6 +57,11 @@
 	RarityMythical  Rarity = "mythical"
 )
 
+var rarities = []Rarity{RarityCommon, RarityUncommon, RarityRare, RarityLegendary, RarityMythical}
+
+// MaxCaptureRate is the highest capture rate a species can have.
+const MaxCaptureRate = 255
+
 // Pokemon represents a Pokemon species with its stats and metadata.
 type Pokemon struct {
 	PokedexID      int
@@ -43,6 +81,49 @@ type Pokemon struct {
 	IsMythical     bool
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	// DeletedAt is when a full import no longer found the species upstream.
+	// Inactive species are kept for the catches that reference them.
+	DeletedAt *time.Time
+}
+
+// ContentHash returns a hash over the imported fields of the Pokemon, so an
+// incremental import can tell whether a stored row changed.
+func (p Pokemon) ContentHash() string {
+	hash := sha256.New()
+
+	fields := []any{
+		p.PokedexID, p.Name, p.Rarity, strings.Join(p.Types, ","), p.SpriteURL,
+		p.HP, p.Attack, p.Defense, p.SpecialAttack, p.SpecialDefense, p.Speed,
+		p.BaseExperience, p.CaptureRate, p.IsLegendary, p.IsMythical,
+	}
+
+	for _, field := range fields {
+		_, _ = fmt.Fprintf(hash, "%v\x00", field)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Validate checks that the Pokemon can be stored in the catalog.
+func (p Pokemon) Validate() error {
+	switch {
+	case p.PokedexID < 1 || p.PokedexID > math.MaxInt32:
+		return fmt.Errorf("%w: pokedex_id %d is out of range", ErrInvalidPokemon, p.PokedexID)
+	case strings.TrimSpace(p.Name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidPokemon)
+	case len(p.Types) == 0 || slices.Contains(p.Types, ""):
+		return fmt.Errorf("%w: types must not be empty", ErrInvalidPokemon)
+	case !slices.Contains(rarities, p.Rarity):
+		return fmt.Errorf("%w: unsupported rarity %q", ErrInvalidPokemon, p.Rarity)
+	case min(p.HP, p.Attack, p.Defense, p.SpecialAttack, p.SpecialDefense, p.Speed, p.BaseExperience) < 0:
+		return fmt.Errorf("%w: stats must not be negative", ErrInvalidPokemon)
+	case max(p.HP, p.Attack, p.Defense, p.SpecialAttack, p.SpecialDefense, p.Speed, p.BaseExperience) > math.MaxInt32:
+		return fmt.Errorf("%w: stats must fit into 32 bits", ErrInvalidPokemon)
+	case p.CaptureRate < 0 || p.CaptureRate > MaxCaptureRate:
+		return fmt.Errorf("%w: capture_rate %d is out of range", ErrInvalidPokemon, p.CaptureRate)
+	}
+
+	return nil
 }
 
 // Import represents a Pokemon data import job.
@@ -50,9 +131,236 @@ type Import struct {
 	ID        uuid.UUID
 	Source    string
 	Status    ImportStatus
+	Mode      ImportMode
 	ItemCount int
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// Upserts splits the imported items by how they changed the catalog.
+	Upserts UpsertCounts
+	// FailedCount is the number of species that could not be imported.
+	FailedCount int
+	// Checkpoint is the highest Pokedex ID up to which every species has been
+	// handled, so a reclaimed import can resume after it.
+	Checkpoint int
+	// ParentID is the import this one retries, if any.
+	ParentID *uuid.UUID
+	Targets  ImportTargets
+	// ExpectedCount is the number of species the import targets in total. It
+	// is zero until a worker resolved the targets.
+	ExpectedCount int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// StartedAt is when a worker first claimed the import.
+	StartedAt *time.Time
+	// FinishedAt is when the import reached a terminal status.
+	FinishedAt *time.Time
+	// ResumeCount is how often the import was recovered after its worker
+	// stopped renewing the lease.
+	ResumeCount int
+	// Failure tells why and where a failed import broke off.
+	Failure *ImportFailure
+}
+
+// ImportPhase is the stage of a running import.
+type ImportPhase string
+
+const (
+	// ImportPhaseSpeciesCount asks the source how many species there are.
+	ImportPhaseSpeciesCount ImportPhase = "species_count"
+	// ImportPhaseFetch reads the targeted species from the source.
+	ImportPhaseFetch ImportPhase = "fetch"
+	// ImportPhaseUpsert writes the fetched species and the import's records
+	// to the database.
+	ImportPhaseUpsert ImportPhase = "upsert"
+	// ImportPhaseRecovery resumes an import whose worker stopped renewing its
+	// lease. An import fails in it once it was resumed too often.
+	ImportPhaseRecovery ImportPhase = "recovery"
+)
+
+// ImportFailure describes why an import failed.
+type ImportFailure struct {
+	Phase   ImportPhase
+	Message string
+	// PersistedCount is the number of species the import had written when it
+	// failed.
+	PersistedCount int
+}
+
+// Completion returns the share of the expected species the import handled,
+// from 0 to 1. It reports false while the expected count is unknown.
+func (imp Import) Completion() (float64, bool) {
+	if imp.ExpectedCount == 0 {
+		return 0, false
+	}
+
+	handled := imp.ItemCount + imp.FailedCount
+
+	return min(1, float64(handled)/float64(imp.ExpectedCount)), true
+}
+
+// EstimatedRemaining extrapolates how long a processing import still takes
+// from its pace since it started. It reports false when there is no pace to
+// extrapolate from yet.
+func (imp Import) EstimatedRemaining(now time.Time) (time.Duration, bool) {
+	completion, ok := imp.Completion()
+	if !ok || completion == 0 || imp.Status != ImportStatusProcessing || imp.StartedAt == nil {
+		return 0, false
+	}
+
+	elapsed := now.Sub(*imp.StartedAt)
+	remaining := time.Duration(float64(elapsed) * (1 - completion) / completion)
+
+	return max(0, remaining), true
+}
+
+// Diff returns the imported fields of fetched that differ from the stored
+// species, in the order of the catalog's columns.
+func (p Pokemon) Diff(fetched Pokemon) []FieldChange {
+	var changes []FieldChange
+
+	changes = appendChange(changes, "name", p.Name, fetched.Name)
+	changes = appendChange(changes, "rarity", p.Rarity, fetched.Rarity)
+
+	if !slices.Equal(p.Types, fetched.Types) {
+		changes = append(changes, FieldChange{Field: "types", Old: p.Types, New: fetched.Types})
+	}
+
+	changes = appendChange(changes, "sprite_url", p.SpriteURL, fetched.SpriteURL)
+	changes = appendChange(changes, "hp", p.HP, fetched.HP)
+	changes = appendChange(changes, "attack", p.Attack, fetched.Attack)
+	changes = appendChange(changes, "defense", p.Defense, fetched.Defense)
+	changes = appendChange(changes, "special_attack", p.SpecialAttack, fetched.SpecialAttack)
+	changes = appendChange(changes, "special_defense", p.SpecialDefense, fetched.SpecialDefense)
+	changes = appendChange(changes, "speed", p.Speed, fetched.Speed)
+	changes = appendChange(changes, "base_experience", p.BaseExperience, fetched.BaseExperience)
+	changes = appendChange(changes, "capture_rate", p.CaptureRate, fetched.CaptureRate)
+	changes = appendChange(changes, "is_legendary", p.IsLegendary, fetched.IsLegendary)
+	changes = appendChange(changes, "is_mythical", p.IsMythical, fetched.IsMythical)
+
+	return changes
+}
+
+func appendChange[T comparable](changes []FieldChange, field string, stored, fetched T) []FieldChange {
+	if stored == fetched {
+		return changes
+	}
+
+	return append(changes, FieldChange{Field: field, Old: stored, New: fetched})
+}
+
+// ImportTargets selects the species an import fetches: every listed Pokedex ID
+// plus the range From..To. A zero From means no range and a zero To runs the
+// range up to the species count. A range never runs past the species count.
+type ImportTargets struct {
+	PokedexIDs []int
+	From       int
+	To         int
+}
+
+// AllSpecies targets every species PokeAPI knows about.
+func AllSpecies() ImportTargets {
+	return ImportTargets{From: 1}
+}
+
+// Empty reports whether the targets select no species at all.
+func (t ImportTargets) Empty() bool {
+	return len(t.PokedexIDs) == 0 && t.From == 0
+}
+
+// coversAllSpecies reports whether the targets include every known species.
+func (t ImportTargets) coversAllSpecies() bool {
+	return t.From == 1 && t.To == 0
+}
+
+// Resolve returns the targeted Pokedex IDs in ascending order without duplicates.
+// The range is cut off at speciesCount.
+func (t ImportTargets) Resolve(speciesCount int) []int {
+	ids := slices.Clone(t.PokedexIDs)
+
+	if t.From > 0 {
+		to := speciesCount
+		if t.To > 0 {
+			to = min(t.To, speciesCount)
+		}
+
+		for id := t.From; id <= to; id++ {
+			ids = append(ids, id)
+		}
+	}
+
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// RetryTargets returns the targets of a retry: the given failed Pokedex IDs
+// plus every target the import never reached. A completed import reached all
+// of its targets.
+func (imp Import) RetryTargets(failedIDs []int) ImportTargets {
+	retry := ImportTargets{PokedexIDs: slices.Clone(failedIDs)}
+
+	if imp.Status != ImportStatusCompleted {
+		for _, id := range imp.Targets.PokedexIDs {
+			if id > imp.Checkpoint {
+				retry.PokedexIDs = append(retry.PokedexIDs, id)
+			}
+		}
+
+		if imp.Targets.From > 0 && (imp.Targets.To == 0 || imp.Checkpoint < imp.Targets.To) {
+			retry.From = max(imp.Targets.From, imp.Checkpoint+1)
+			retry.To = imp.Targets.To
+		}
+	}
+
+	slices.Sort(retry.PokedexIDs)
+	retry.PokedexIDs = slices.Compact(retry.PokedexIDs)
+
+	return retry
+}
+
+// ImportProgress is the persisted progress of a running import.
+type ImportProgress struct {
+	ItemCount  int
+	Checkpoint int
+	Upserts    UpsertCounts
+}
+
+// UpsertCounts counts catalog rows by how an upsert changed them.
+type UpsertCounts struct {
+	Inserted  int
+	Updated   int
+	Unchanged int
+}
+
+// Add returns the sum of both counts.
+func (c UpsertCounts) Add(other UpsertCounts) UpsertCounts {
+	return UpsertCounts{
+		Inserted:  c.Inserted + other.Inserted,
+		Updated:   c.Updated + other.Updated,
+		Unchanged: c.Unchanged + other.Unchanged,
+	}
+}
+
+// ImportMode selects how an import writes fetched species to the catalog.
+type ImportMode string
+
+const (
+	// ImportModeFull rewrites every fetched species.
+	ImportModeFull ImportMode = "full"
+	// ImportModeIncremental skips species whose content hash did not change.
+	ImportModeIncremental ImportMode = "incremental"
+	// ImportModeDryRun leaves the catalog untouched and records how the
+	// fetched species differ from it instead.
+	ImportModeDryRun ImportMode = "dry_run"
+)
+
+// CreateImportParams holds the options of a new import.
+type CreateImportParams struct {
+	Source string
+	// Mode defaults to ImportModeFull.
+	Mode ImportMode
+	// Targets defaults to AllSpecies. File imports target their uploaded rows.
+	Targets ImportTargets
+	// Upload holds the species of a SourceFile import.
+	Upload []Pokemon
 }
 
 // ImportStatus represents the current state of an import.
@@ -61,10 +369,153 @@ type Import struct {
 const (
 	ImportStatusPending    ImportStatus = "pending"
 	ImportStatusProcessing ImportStatus = "processing"
-	ImportStatusCompleted  ImportStatus = "completed"
-	ImportStatusFailed     ImportStatus = "failed"
+	// ImportStatusInterrupted is an import its worker stopped while shutting
+	// down. It is resumed from its checkpoint by the next worker.
+	ImportStatusInterrupted ImportStatus = "interrupted"
+	ImportStatusCompleted   ImportStatus = "completed"
+	ImportStatusFailed      ImportStatus = "failed"
+	ImportStatusCancelled   ImportStatus = "cancelled"
+)
+
+// Finished reports whether an import in this status will not change anymore.
+func (s ImportStatus) Finished() bool {
+	return s == ImportStatusCompleted || s == ImportStatusFailed || s == ImportStatusCancelled
+}
+
+// ImportEvent is a snapshot of an import's progress streamed to watchers.
+//
+// Fetched is only known on the replica running the import. Elsewhere it
+// equals Persisted. Expected is zero until the targets were resolved.
+type ImportEvent struct {
+	ImportID  uuid.UUID
+	Status    ImportStatus
+	Fetched   int
+	Persisted int
+	Failed    int
+	Expected  int
+}
+
+// DiffKind tells how a species of a dry-run import differs from the catalog.
+type DiffKind string
+
+const (
+	// DiffKindNew is a fetched species the catalog does not have yet.
+	DiffKindNew DiffKind = "new"
+	// DiffKindRemoved is a catalog species the source no longer knows about.
+	DiffKindRemoved DiffKind = "removed"
+	// DiffKindChanged is a catalog species with fields the import would change,
+	// or an inactive one it would reactivate.
+	DiffKindChanged DiffKind = "changed"
+	// DiffKindUnchanged is a catalog species the import fetched as it is. It
+	// is only recorded to tell which species a dry run has seen.
+	DiffKindUnchanged DiffKind = "unchanged"
+)
+
+// SpeciesDiff is a species a dry-run import would add, remove or change.
+type SpeciesDiff struct {
+	PokedexID int
+	Name      string
+	Kind      DiffKind
+	// Changes lists the changed fields of a DiffKindChanged species.
+	Changes []FieldChange
+}
+
+// FieldChange is a catalog field an import would overwrite.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// PokemonChange records the fields an import changed on a catalog species.
+type PokemonChange struct {
+	PokedexID int
+	ImportID  uuid.UUID
+	Changes   []FieldChange
+	ChangedAt time.Time
+}
+
+// DiffSummary counts the species of a diff report by kind.
+type DiffSummary struct {
+	New     int
+	Removed int
+	Changed int
+}
+
+// Count returns the number of species of the given kind, or of every reported
+// kind when kind is nil.
+func (s DiffSummary) Count(kind *DiffKind) int {
+	if kind == nil {
+		return s.New + s.Removed + s.Changed
+	}
+
+	switch *kind {
+	case DiffKindNew:
+		return s.New
+	case DiffKindRemoved:
+		return s.Removed
+	case DiffKindChanged:
+		return s.Changed
+	case DiffKindUnchanged:
+		// Unchanged species are not part of the report.
+	}
+
+	return 0
+}
+
+// ItemErrorClass categorizes why a single species could not be imported.
+type ItemErrorClass string
+
+const (
+	ItemErrorClassHTTPStatus ItemErrorClass = "http_status"
+	ItemErrorClassTimeout    ItemErrorClass = "timeout"
+	ItemErrorClassMapping    ItemErrorClass = "mapping"
+	ItemErrorClassTransport  ItemErrorClass = "transport"
 )
 
+// ItemError records a species that was skipped during an import.
+type ItemError struct {
+	PokedexID int
+	Class     ItemErrorClass
+	// HTTPStatus is the upstream response status, set for ItemErrorClassHTTPStatus.
+	HTTPStatus int
+	Message    string
+	CreatedAt  time.Time
+}
+
+// FetchError is returned by a Fetcher to classify a failed fetch.
+type FetchError struct {
+	Class      ItemErrorClass
+	HTTPStatus int
+	Err        error
+}
+
+// Error returns the message of the underlying error.
+func (e *FetchError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// ImportInFlightError is returned when an import cannot be queued because an
+// import of the same source is still pending or processing.
+type ImportInFlightError struct {
+	Active Import
+}
+
+// Error names the in-flight import.
+func (e *ImportInFlightError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrImportInFlight, e.Active.ID)
+}
+
+// Unwrap returns ErrImportInFlight.
+func (e *ImportInFlightError) Unwrap() error {
+	return ErrImportInFlight
+}
+
 // BaseExperience thresholds for rarity assignment.
 const (
 	RareBaseExperienceThreshold     = 200
@@ -74,29 +525,212 @@ type Import struct {
 // ListParams holds catalog query options.
 type ListParams struct {
 	Rarity *Rarity
+	// IncludeInactive also lists species that were removed upstream.
+	IncludeInactive bool
+	Limit           int
+	Offset          int
+}
+
+// ImportFilter narrows the import history to matching imports.
+// Nil fields and zero times are not applied.
+type ImportFilter struct {
+	Status        *ImportStatus
+	Source        *string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// ImportListParams holds import history query options.
+type ImportListParams struct {
+	Filter ImportFilter
 	Limit  int
 	Offset int
 }
 
+// Schedule tells when a recurring import runs next. A zero time means never.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// ScheduledImport creates an import with its params on every tick of its schedule.
+type ScheduledImport struct {
+	Name     string
+	Schedule Schedule
+	Params   CreateImportParams
+}
+
 // Fetcher fetches Pokemon data from an external source.
+//
+// A species whose fetch fails is skipped and recorded as an import error,
+// unless the error wraps ErrSourceUnavailable, which fails the whole import.
 type Fetcher interface {
 	FetchSpeciesCount(ctx context.Context) (int, error)
 	FetchPokemon(ctx context.Context, id int) (*Pokemon, error)
 }
 
+// Sources maps the name of each import source to the Fetcher it reads from.
+type Sources map[string]Fetcher
+
 // ImportStore persists import state.
+//
+// Only one import per source can be pending or processing at a time. Creating
+// another one fails with ErrImportInFlight.
 type ImportStore interface {
 	CreateImport(ctx context.Context, imp Import) error
 	GetImport(ctx context.Context, id uuid.UUID) (Import, error)
-	UpdateImportStatus(ctx context.Context, id uuid.UUID, status ImportStatus, itemCount int) error
+	GetActiveImport(ctx context.Context, source string) (Import, error)
+	CancelImport(ctx context.Context, id uuid.UUID) (Import, error)
+	ListImports(ctx context.Context, params ImportListParams) ([]Import, error)
+	CountImports(ctx context.Context, filter ImportFilter) (int64, error)
+}
+
+// ImportErrorStore persists the species an import had to skip.
+//
+// Recording or clearing errors keeps the import's failed count in sync.
+// Recording is scoped to the lease owner like the writes of ImportQueue, and
+// returns ErrImportLeaseLost once another worker holds the import.
+type ImportErrorStore interface {
+	RecordImportError(ctx context.Context, importID uuid.UUID, owner string, itemErr ItemError) error
+	ClearImportErrors(ctx context.Context, importID uuid.UUID, afterPokedexID int) error
+	ListImportErrorIDs(ctx context.Context, importID uuid.UUID) ([]int, error)
+	ListImportErrors(ctx context.Context, importID uuid.UUID, limit, offset int) ([]ItemError, error)
+}
+
+// ImportDiffStore persists the diff reports of dry-run imports.
+//
+// Recording the diff of a species again replaces it, so a resumed dry run can
+// compare the species after its checkpoint once more. RecordRemovedSpecies
+// records every catalog species the import neither has a diff for nor failed
+// to fetch as removed. Listing without a kind leaves out unchanged species.
+type ImportDiffStore interface {
+	RecordImportDiffs(ctx context.Context, importID uuid.UUID, diffs []SpeciesDiff) error
+	RecordRemovedSpecies(ctx context.Context, importID uuid.UUID) error
+	ListImportDiffs(ctx context.Context, importID uuid.UUID, kind *DiffKind, limit, offset int) ([]SpeciesDiff, error)
+	SummarizeImportDiffs(ctx context.Context, importID uuid.UUID) (DiffSummary, error)
+}
+
+// ImportUploadStore persists the species uploaded for file imports.
+//
+// CreateImportWithUpload creates the import together with its upload, so a
+// worker never claims a file import whose rows are not stored yet.
+type ImportUploadStore interface {
+	CreateImportWithUpload(ctx context.Context, imp Import, upload []Pokemon) error
+	GetUploadedPokemon(ctx context.Context, importID uuid.UUID, pokedexID int) (Pokemon, error)
+	ListUploadedPokemon(ctx context.Context, importID uuid.UUID, pokedexIDs []int) ([]Pokemon, error)
+}
+
+// ImportQueue hands out queued imports to workers under a renewable lease.
+//
+// ClaimImport reclaims a processing import whose lease expired, counting it as
+// a resume, only while it was resumed fewer than maxResumes times.
+//
+// Every write is scoped to the lease owner, so a worker that lost its lease
+// gets ErrImportLeaseLost instead of overwriting the new owner's progress.
+// Progress can still be recorded after an import was cancelled, so the import
+// keeps how far it got. InterruptImport releases the lease of an import the
+// owner stops early, so another worker claims it without waiting for the lease
+// to expire. FailImport keeps the persisted count of the import with its
+// failure.
+type ImportQueue interface {
+	ClaimImport(ctx context.Context, owner string, lease time.Duration, maxResumes int) (Import, error)
+	RenewImportLease(ctx context.Context, id uuid.UUID, owner string, lease time.Duration) error
+	UpdateImportExpectedCount(ctx context.Context, id uuid.UUID, owner string, expected int) error
+	UpdateImportProgress(ctx context.Context, id uuid.UUID, owner string, progress ImportProgress) error
+	FinishImport(ctx context.Context, id uuid.UUID, owner string, status ImportStatus) error
+	FailImport(ctx context.Context, id uuid.UUID, owner string, phase ImportPhase, message string) error
+	InterruptImport(ctx context.Context, id uuid.UUID, owner string) error
+}
+
+// StaleImportStore finds and recovers processing imports whose lease expired
+// and that were not updated since the given time, because their worker died
+// and no other worker reclaimed them.
+//
+// ResumeStaleImport marks the import interrupted, counting a resume, and
+// FailStaleImport marks it failed in ImportPhaseRecovery with the message,
+// keeping its persisted count. Both only change an import that was not updated
+// since it was listed, and report whether they did.
+type StaleImportStore interface {
+	ListStaleImports(ctx context.Context, updatedBefore time.Time) ([]Import, error)
+	ResumeStaleImport(ctx context.Context, imp Import) (bool, error)
+	FailStaleImport(ctx context.Context, imp Import, message string) (bool, error)
+}
+
+// ScheduleStore coordinates scheduled imports across replicas.
+//
+// FireSchedule calls fire for a tick of the named schedule unless another
+// replica is firing the schedule right now or already fired the tick. The tick
+// only counts as fired when fire succeeds, and the imports fire creates through
+// the given ImportStore only exist once it does. It reports whether fire ran.
+type ScheduleStore interface {
+	FireSchedule(
+		ctx context.Context,
+		name string,
+		tick time.Time,
+		fire func(ctx context.Context, imports ImportStore) error,
+	) (bool, error)
+}
+
+// CatalogSeeder loads a snapshot into an empty catalog.
+//
+// SeedCatalog stores the Pokemon only while the catalog holds none, even when
+// several replicas seed it at once, and returns ErrCatalogNotEmpty otherwise.
+type CatalogSeeder interface {
+	SeedCatalog(ctx context.Context, pokemon []Pokemon) error
 }
 
 // CatalogStore persists and queries Pokemon catalog data.
+//
+// UpsertPokemonBatch stores each Pokemon's ContentHash and leaves rows whose
+// hash is unchanged untouched when skipUnchanged is set. Every written row is
+// marked as seen by the import and reactivated if it was inactive. The fields
+// it changes on existing rows are recorded as a PokemonChange in the same
+// transaction.
+//
+// DeactivateUnseenPokemon marks every active species the import neither wrote
+// nor failed to fetch as inactive and returns how many it marked.
 type CatalogStore interface {
-	UpsertPokemonBatch(ctx context.Context, pokemon []Pokemon) error
+	UpsertPokemonBatch(
+		ctx context.Context,
+		importID uuid.UUID,
+		pokemon []Pokemon,
+		skipUnchanged bool,
+	) (UpsertCounts, error)
+	DeactivateUnseenPokemon(ctx context.Context, importID uuid.UUID) (int64, error)
 	GetPokemonByID(ctx context.Context, pokedexID int) (Pokemon, error)
+	ListPokemonByIDs(ctx context.Context, pokedexIDs []int) ([]Pokemon, error)
 	ListPokemon(ctx context.Context, params ListParams) ([]Pokemon, error)
-	CountPokemon(ctx context.Context, rarity *Rarity) (int64, error)
+	CountPokemon(ctx context.Context, rarity *Rarity, includeInactive bool) (int64, error)
+}
+
+// PokemonHistoryStore lists the changes imports made to a catalog species,
+// newest first.
+type PokemonHistoryStore interface {
+	ListPokemonHistory(ctx context.Context, pokedexID, limit, offset int) ([]PokemonChange, error)
+	CountPokemonHistory(ctx context.Context, pokedexID int) (int64, error)
+}
+
+// NewItemError classifies a failed fetch of the given species.
+//
+// Errors that are not a FetchError count as timeouts when a deadline was
+// exceeded and as transport errors otherwise.
+func NewItemError(pokedexID int, err error) ItemError {
+	itemErr := ItemError{
+		PokedexID: pokedexID,
+		Class:     ItemErrorClassTransport,
+		Message:   err.Error(),
+	}
+
+	var fetchErr *FetchError
+
+	switch {
+	case errors.As(err, &fetchErr):
+		itemErr.Class = fetchErr.Class
+		itemErr.HTTPStatus = fetchErr.HTTPStatus
+	case errors.Is(err, context.DeadlineExceeded):
+		itemErr.Class = ItemErrorClassTimeout
+	}
+
+	return itemErr
 }
 
 // AssignRarity determines a Pokemon's rarity tier based on PokeAPI data.
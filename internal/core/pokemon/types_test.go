@@ -1,8 +1,12 @@
 package pokemon_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"reference-service-go/internal/core/pokemon"
 	"testing"
+	"time"
 
 	"github.com/monkescience/testastic"
 )
@@ -54,3 +58,370 @@ func TestAssignRarity(t *testing.T) {
 		})
 	}
 }
+
+func TestNewItemError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantClass  pokemon.ItemErrorClass
+		wantStatus int
+	}{
+		{
+			name: "keeps the class and status of a fetch error",
+			err: fmt.Errorf("wrapped: %w", &pokemon.FetchError{
+				Class:      pokemon.ItemErrorClassHTTPStatus,
+				HTTPStatus: 503,
+				Err:        errors.New("unexpected status 503"),
+			}),
+			wantClass:  pokemon.ItemErrorClassHTTPStatus,
+			wantStatus: 503,
+		},
+		{
+			name:      "treats an exceeded deadline as a timeout",
+			err:       fmt.Errorf("fetching pokemon 25: %w", context.DeadlineExceeded),
+			wantClass: pokemon.ItemErrorClassTimeout,
+		},
+		{
+			name:      "falls back to a transport error",
+			err:       errors.New("connection refused"),
+			wantClass: pokemon.ItemErrorClassTransport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := pokemon.NewItemError(25, tt.err)
+
+			testastic.Equal(t, 25, got.PokedexID)
+			testastic.Equal(t, tt.wantClass, got.Class)
+			testastic.Equal(t, tt.wantStatus, got.HTTPStatus)
+			testastic.Equal(t, tt.err.Error(), got.Message)
+		})
+	}
+}
+
+func TestImportTargetsResolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		targets pokemon.ImportTargets
+		want    []int
+	}{
+		{
+			name:    "open range runs up to the species count",
+			targets: pokemon.AllSpecies(),
+			want:    []int{1, 2, 3},
+		},
+		{
+			name:    "merges listed ids with a closed range",
+			targets: pokemon.ImportTargets{PokedexIDs: []int{7, 2}, From: 2, To: 3},
+			want:    []int{2, 3, 7},
+		},
+		{
+			name:    "closed range stops at the species count",
+			targets: pokemon.ImportTargets{From: 2, To: 2147483647},
+			want:    []int{2, 3},
+		},
+		{
+			name:    "range past the species count selects nothing",
+			targets: pokemon.ImportTargets{From: 4, To: 9},
+			want:    nil,
+		},
+		{
+			name:    "no range selects only listed ids",
+			targets: pokemon.ImportTargets{PokedexIDs: []int{5}},
+			want:    []int{5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.targets.Resolve(3)
+
+			testastic.SliceEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestImportRetryTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries failed ids and the unreached rest of an interrupted import", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a failed import that stopped after Pokedex ID 40
+		imp := pokemon.Import{
+			Status:     pokemon.ImportStatusFailed,
+			Checkpoint: 40,
+			Targets:    pokemon.AllSpecies(),
+		}
+
+		// when: computing the retry targets
+		got := imp.RetryTargets([]int{25, 12})
+
+		// then: the failed ids and everything after the checkpoint are targeted
+		testastic.DeepEqual(t, pokemon.ImportTargets{PokedexIDs: []int{12, 25}, From: 41}, got)
+	})
+
+	t.Run("retries only unreached listed ids", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a cancelled import of listed ids that got past the first one
+		imp := pokemon.Import{
+			Status:     pokemon.ImportStatusCancelled,
+			Checkpoint: 4,
+			Targets:    pokemon.ImportTargets{PokedexIDs: []int{4, 9, 16}},
+		}
+
+		// when: computing the retry targets
+		got := imp.RetryTargets(nil)
+
+		// then: only the unreached ids are targeted
+		testastic.DeepEqual(t, pokemon.ImportTargets{PokedexIDs: []int{9, 16}}, got)
+	})
+
+	t.Run("retries only failed ids of a completed import", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a completed import
+		imp := pokemon.Import{
+			Status:     pokemon.ImportStatusCompleted,
+			Checkpoint: 1025,
+			Targets:    pokemon.AllSpecies(),
+		}
+
+		// when: computing the retry targets without failures
+		got := imp.RetryTargets(nil)
+
+		// then: nothing is left to retry
+		testastic.True(t, got.Empty())
+	})
+}
+
+func TestImportCompletion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts persisted and failed species against the expected total", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an import that handled 30 of 120 species
+		imp := pokemon.Import{ItemCount: 25, FailedCount: 5, ExpectedCount: 120}
+
+		// when: computing its completion
+		got, ok := imp.Completion()
+
+		// then: a quarter of the import is done
+		testastic.True(t, ok)
+		testastic.Equal(t, 0.25, got)
+	})
+
+	t.Run("is unknown before the targets were resolved", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an import without an expected count
+		imp := pokemon.Import{Status: pokemon.ImportStatusPending}
+
+		// when: computing its completion
+		_, ok := imp.Completion()
+
+		// then: no completion is reported
+		testastic.False(t, ok)
+	})
+}
+
+func TestImportEstimatedRemaining(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("extrapolates the pace since the import started", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a processing import that handled a quarter of its species in a minute
+		imp := pokemon.Import{
+			Status:        pokemon.ImportStatusProcessing,
+			ItemCount:     30,
+			ExpectedCount: 120,
+			StartedAt:     &startedAt,
+		}
+
+		// when: estimating the remaining time
+		got, ok := imp.EstimatedRemaining(startedAt.Add(time.Minute))
+
+		// then: the rest takes three more minutes
+		testastic.True(t, ok)
+		testastic.Equal(t, 3*time.Minute, got)
+	})
+
+	t.Run("is unknown before any species was handled", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a processing import without progress
+		imp := pokemon.Import{
+			Status:        pokemon.ImportStatusProcessing,
+			ExpectedCount: 120,
+			StartedAt:     &startedAt,
+		}
+
+		// when: estimating the remaining time
+		_, ok := imp.EstimatedRemaining(startedAt.Add(time.Minute))
+
+		// then: no estimate is reported
+		testastic.False(t, ok)
+	})
+
+	t.Run("is unknown once the import finished", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a completed import
+		imp := pokemon.Import{
+			Status:        pokemon.ImportStatusCompleted,
+			ItemCount:     120,
+			ExpectedCount: 120,
+			StartedAt:     &startedAt,
+		}
+
+		// when: estimating the remaining time
+		_, ok := imp.EstimatedRemaining(startedAt.Add(time.Minute))
+
+		// then: no estimate is reported
+		testastic.False(t, ok)
+	})
+}
+
+func TestPokemonContentHash(t *testing.T) {
+	t.Parallel()
+
+	base := pokemon.Pokemon{
+		PokedexID: 25,
+		Name:      "pikachu",
+		Rarity:    pokemon.RarityUncommon,
+		Types:     []string{"electric"},
+		HP:        35,
+	}
+
+	t.Run("ignores timestamps", func(t *testing.T) {
+		t.Parallel()
+
+		// given: the same species written at another time
+		rewritten := base
+		rewritten.UpdatedAt = time.Now()
+
+		// when / then: the content hash is the same
+		testastic.Equal(t, base.ContentHash(), rewritten.ContentHash())
+	})
+
+	t.Run("changes with the stats", func(t *testing.T) {
+		t.Parallel()
+
+		// given: the species after a stat change
+		rebalanced := base
+		rebalanced.HP = 45
+
+		// when / then: the content hash differs
+		testastic.NotEqual(t, base.ContentHash(), rebalanced.ContentHash())
+	})
+}
+
+func TestPokemonDiff(t *testing.T) {
+	t.Parallel()
+
+	stored := pokemon.Pokemon{
+		PokedexID: 25,
+		Name:      "pikachu",
+		Rarity:    pokemon.RarityUncommon,
+		Types:     []string{"electric"},
+		HP:        35,
+		CreatedAt: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("lists changed fields in column order", func(t *testing.T) {
+		t.Parallel()
+
+		// given: the species fetched with another rarity, types and stats
+		fetched := stored
+		fetched.Rarity = pokemon.RarityRare
+		fetched.Types = []string{"electric", "fairy"}
+		fetched.HP = 45
+
+		// when: diffing it against the stored species
+		got := stored.Diff(fetched)
+
+		// then: every changed field is listed with its old and new value
+		testastic.DeepEqual(t, []pokemon.FieldChange{
+			{Field: "rarity", Old: pokemon.RarityUncommon, New: pokemon.RarityRare},
+			{Field: "types", Old: []string{"electric"}, New: []string{"electric", "fairy"}},
+			{Field: "hp", Old: 35, New: 45},
+		}, got)
+	})
+
+	t.Run("ignores timestamps", func(t *testing.T) {
+		t.Parallel()
+
+		// given: the same species without its stored timestamps
+		fetched := stored
+		fetched.CreatedAt = time.Time{}
+
+		// when / then: nothing changed
+		testastic.Empty(t, stored.Diff(fetched))
+	})
+}
+
+func TestDiffSummaryCount(t *testing.T) {
+	t.Parallel()
+
+	summary := pokemon.DiffSummary{New: 2, Removed: 1, Changed: 14}
+	changed := pokemon.DiffKindChanged
+
+	testastic.Equal(t, 14, summary.Count(&changed))
+	testastic.Equal(t, 17, summary.Count(nil))
+}
+
+func TestPokemonValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := pokemon.Pokemon{
+		PokedexID: 25,
+		Name:      "pikachu",
+		Rarity:    pokemon.RarityUncommon,
+		Types:     []string{"electric"},
+		HP:        35,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(p *pokemon.Pokemon)
+		wantErr bool
+	}{
+		{name: "valid pokemon", modify: func(*pokemon.Pokemon) {}},
+		{name: "missing pokedex id", modify: func(p *pokemon.Pokemon) { p.PokedexID = 0 }, wantErr: true},
+		{name: "blank name", modify: func(p *pokemon.Pokemon) { p.Name = " " }, wantErr: true},
+		{name: "no types", modify: func(p *pokemon.Pokemon) { p.Types = nil }, wantErr: true},
+		{name: "unknown rarity", modify: func(p *pokemon.Pokemon) { p.Rarity = "epic" }, wantErr: true},
+		{name: "negative stat", modify: func(p *pokemon.Pokemon) { p.Speed = -1 }, wantErr: true},
+		{name: "capture rate above maximum", modify: func(p *pokemon.Pokemon) { p.CaptureRate = 256 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: a pokemon with one field changed
+			p := valid
+			tt.modify(&p)
+
+			// when: it is validated
+			err := p.Validate()
+
+			// then: only invalid pokemon are rejected
+			testastic.Equal(t, tt.wantErr, errors.Is(err, pokemon.ErrInvalidPokemon))
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errUploadSpeciesCount = errors.New("file imports have no species count")
+
+// phaseError is an import error together with the phase it happened in.
+type phaseError struct {
+	phase ImportPhase
+	err   error
+}
+
+func (e *phaseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *phaseError) Unwrap() error {
+	return e.err
+}
+
+// inPhase attributes err to the given phase of an import.
+func inPhase(phase ImportPhase, err error) error {
+	return &phaseError{phase: phase, err: err}
+}
+
+func (s *Service) work(ctx context.Context) {
+	slog.InfoContext(ctx, "import worker started", slog.String("worker_id", s.workerID))
+
+	ticker := time.NewTicker(s.worker.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.drainQueue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *Service) drainQueue(ctx context.Context) {
+	for ctx.Err() == nil {
+		imp, err := s.queue.ClaimImport(ctx, s.workerID, s.worker.LeaseDuration, s.worker.MaxResumes)
+		if errors.Is(err, ErrNoImportQueued) {
+			return
+		}
+
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "failed to claim import", slog.Any("error", err))
+			}
+
+			return
+		}
+
+		s.runImport(ctx, imp)
+	}
+}
+
+func (s *Service) runImport(ctx context.Context, imp Import) {
+	// An import outlives the worker loop: Shutdown drains it, and interrupts it
+	// once the drain timeout passed.
+	ctx = context.WithoutCancel(ctx)
+	idStr := imp.ID.String()
+	slog.InfoContext(ctx, "starting import",
+		slog.String("import_id", idStr),
+		slog.String("mode", string(imp.Mode)),
+		slog.Int("checkpoint", imp.Checkpoint),
+	)
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+
+	run := newRunningImport(imp.ID, cancel)
+	run.fetched.Store(int64(imp.ItemCount))
+
+	defer s.supervisor.track(run)()
+
+	heartbeatDone := make(chan struct{})
+
+	go func() {
+		defer close(heartbeatDone)
+
+		s.keepLease(runCtx, cancel, imp.ID)
+	}()
+
+	err := s.importCatalog(runCtx, imp, run)
+
+	cancel(nil)
+	<-heartbeatDone
+
+	switch {
+	case errors.Is(err, ErrImportInterrupted), errors.Is(context.Cause(runCtx), ErrImportInterrupted):
+		slog.InfoContext(ctx, "import interrupted by shutdown", slog.String("import_id", idStr))
+		s.interruptImport(ctx, imp.ID)
+	case errors.Is(context.Cause(runCtx), ErrImportCancelled):
+		slog.InfoContext(ctx, "import cancelled", slog.String("import_id", idStr))
+	case errors.Is(err, ErrImportLeaseLost), errors.Is(context.Cause(runCtx), ErrImportLeaseLost):
+		slog.WarnContext(ctx, "import lease lost, abandoning import", slog.String("import_id", idStr))
+	case err != nil:
+		slog.ErrorContext(ctx, "import failed", slog.String("import_id", idStr), slog.Any("error", err))
+		s.failImport(ctx, imp.ID, err)
+	default:
+		s.finishImport(ctx, imp.ID, ImportStatusCompleted)
+		slog.InfoContext(ctx, "import completed", slog.String("import_id", idStr))
+	}
+}
+
+func (s *Service) keepLease(ctx context.Context, cancel context.CancelCauseFunc, importID uuid.UUID) {
+	ticker := time.NewTicker(s.worker.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := s.queue.RenewImportLease(ctx, importID, s.workerID, s.worker.LeaseDuration)
+		if errors.Is(err, ErrImportLeaseLost) {
+			cancel(s.leaseLossCause(ctx, importID))
+
+			return
+		}
+
+		if err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to renew import lease", slog.Any("error", err))
+		}
+	}
+}
+
+// leaseLossCause tells a cancellation from another worker taking over the import.
+func (s *Service) leaseLossCause(ctx context.Context, importID uuid.UUID) error {
+	imp, err := s.imports.GetImport(ctx, importID)
+	if err == nil && imp.Status == ImportStatusCancelled {
+		return ErrImportCancelled
+	}
+
+	return ErrImportLeaseLost
+}
+
+func (s *Service) importCatalog(ctx context.Context, imp Import, run *runningImport) error {
+	// Species after the checkpoint are fetched again, so their earlier errors no longer apply.
+	err := s.importErrors.ClearImportErrors(ctx, imp.ID, imp.Checkpoint)
+	if err != nil {
+		return fmt.Errorf("clearing import errors: %w", err)
+	}
+
+	fetcher, err := s.fetcherFor(imp)
+	if err != nil {
+		return inPhase(ImportPhaseFetch, err)
+	}
+
+	// Only a range needs to know how many species there are, as it never runs
+	// past the last one.
+	count := 0
+
+	if imp.Targets.From > 0 {
+		count, err = fetcher.FetchSpeciesCount(ctx)
+		if err != nil {
+			return inPhase(ImportPhaseSpeciesCount, fmt.Errorf("fetching species count: %w", err))
+		}
+	}
+
+	targets := imp.Targets.Resolve(count)
+
+	err = s.recordExpectedCount(ctx, imp, run, len(targets))
+	if err != nil {
+		return err
+	}
+
+	var ids []int
+
+	for _, id := range targets {
+		if id > imp.Checkpoint {
+			ids = append(ids, id)
+		}
+	}
+
+	slog.InfoContext(ctx, "fetching pokemon",
+		slog.Int("total", len(ids)),
+		slog.Int("resume_after", imp.Checkpoint),
+	)
+
+	if len(ids) > 0 {
+		err = s.streamImport(ctx, imp, run, ids, fetcher.FetchPokemon)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only an import over every known species can tell which ones were removed.
+	if !imp.Targets.coversAllSpecies() {
+		return nil
+	}
+
+	switch imp.Mode {
+	case ImportModeDryRun:
+		err = s.diffs.RecordRemovedSpecies(ctx, imp.ID)
+		if err != nil {
+			return fmt.Errorf("recording removed species: %w", err)
+		}
+	case ImportModeFull:
+		err = s.deactivateRemovedSpecies(ctx, imp.ID)
+		if err != nil {
+			return err
+		}
+	case ImportModeIncremental:
+		// Unchanged species are skipped, so there is no telling which were seen.
+	}
+
+	return nil
+}
+
+// deactivateRemovedSpecies marks the species a full import did not find
+// upstream as inactive.
+func (s *Service) deactivateRemovedSpecies(ctx context.Context, importID uuid.UUID) error {
+	deactivated, err := s.catalog.DeactivateUnseenPokemon(ctx, importID)
+	if err != nil {
+		return fmt.Errorf("deactivating removed species: %w", err)
+	}
+
+	if deactivated > 0 {
+		slog.InfoContext(ctx, "deactivated species removed upstream", slog.Int64("count", deactivated))
+	}
+
+	return nil
+}
+
+func (s *Service) recordExpectedCount(ctx context.Context, imp Import, run *runningImport, expected int) error {
+	run.expected.Store(int64(expected))
+
+	if imp.ExpectedCount == expected {
+		return nil
+	}
+
+	err := s.queue.UpdateImportExpectedCount(ctx, imp.ID, s.workerID, expected)
+	if errors.Is(err, ErrImportLeaseLost) {
+		return err
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update import expected count", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// fetcherFor returns the fetcher of the source the given import reads from.
+func (s *Service) fetcherFor(imp Import) (Fetcher, error) {
+	if imp.Source == SourceFile {
+		return uploadFetcher{uploads: s.uploads, importID: imp.ID}, nil
+	}
+
+	fetcher, ok := s.sources[imp.Source]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSource, imp.Source)
+	}
+
+	return fetcher, nil
+}
+
+func (s *Service) finishImport(ctx context.Context, importID uuid.UUID, status ImportStatus) {
+	err := s.queue.FinishImport(ctx, importID, s.workerID, status)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to finish import",
+			slog.String("status", string(status)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// failImport marks the import failed in the phase the error happened in.
+func (s *Service) failImport(ctx context.Context, importID uuid.UUID, cause error) {
+	// Apart from fetching, everything an import does writes to the database.
+	phase := ImportPhaseUpsert
+
+	var phaseErr *phaseError
+	if errors.As(cause, &phaseErr) {
+		phase = phaseErr.phase
+	}
+
+	err := s.queue.FailImport(ctx, importID, s.workerID, phase, cause.Error())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to mark import as failed",
+			slog.String("phase", string(phase)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (s *Service) interruptImport(ctx context.Context, importID uuid.UUID) {
+	err := s.queue.InterruptImport(ctx, importID, s.workerID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to interrupt import", slog.Any("error", err))
+	}
+}
+
+// uploadFetcher reads the species of a file import from its stored upload.
+type uploadFetcher struct {
+	uploads  ImportUploadStore
+	importID uuid.UUID
+}
+
+// FetchSpeciesCount fails because a file import always targets the uploaded
+// species and never an open-ended range.
+func (f uploadFetcher) FetchSpeciesCount(context.Context) (int, error) {
+	return 0, errUploadSpeciesCount
+}
+
+// FetchPokemon reads an uploaded species.
+func (f uploadFetcher) FetchPokemon(ctx context.Context, pokedexID int) (*Pokemon, error) {
+	p, err := f.uploads.GetUploadedPokemon(ctx, f.importID, pokedexID)
+	if err != nil {
+		return nil, fmt.Errorf("reading uploaded pokemon %d: %w", pokedexID, err)
+	}
+
+	return &p, nil
+}
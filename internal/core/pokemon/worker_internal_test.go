@@ -0,0 +1,109 @@
+package pokemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/monkescience/testastic"
+)
+
+// fakeFetcher serves every species, or fails counting or fetching them.
+type fakeFetcher struct {
+	countErr error
+	fetchErr error
+}
+
+func (f fakeFetcher) FetchSpeciesCount(context.Context) (int, error) {
+	if f.countErr != nil {
+		return 0, f.countErr
+	}
+
+	return 2, nil
+}
+
+func (f fakeFetcher) FetchPokemon(ctx context.Context, pokedexID int) (*Pokemon, error) {
+	if f.fetchErr != nil {
+		return nil, f.fetchErr
+	}
+
+	return fetchSpecies(ctx, pokedexID)
+}
+
+func TestFailImportPhase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		source      string
+		targets     ImportTargets
+		fetcher     fakeFetcher
+		catalogErr  error
+		wantPhase   ImportPhase
+		wantMessage string
+	}{
+		{
+			name:        "species count unavailable",
+			targets:     AllSpecies(),
+			fetcher:     fakeFetcher{countErr: errNotFound},
+			wantPhase:   ImportPhaseSpeciesCount,
+			wantMessage: "fetching species count: not found",
+		},
+		{
+			name:        "unknown source",
+			source:      "mirror",
+			wantPhase:   ImportPhaseFetch,
+			wantMessage: `unknown import source "mirror"`,
+		},
+		{
+			name:        "source unavailable",
+			fetcher:     fakeFetcher{fetchErr: ErrSourceUnavailable},
+			wantPhase:   ImportPhaseFetch,
+			wantMessage: "import source unavailable",
+		},
+		{
+			name:        "catalog write failed",
+			catalogErr:  errCatalogDown,
+			wantPhase:   ImportPhaseUpsert,
+			wantMessage: "upserting batch: catalog down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: an import that breaks off in one of its phases
+			queue := &fakeQueue{}
+			service := newPipelineService(&fakeCatalog{err: tt.catalogErr}, queue, time.Hour)
+			service.importErrors = &fakeImportErrors{}
+			service.sources = Sources{"pokeapi": tt.fetcher}
+
+			source := tt.source
+			if source == "" {
+				source = "pokeapi"
+			}
+
+			targets := tt.targets
+			if targets.Empty() {
+				targets = ImportTargets{PokedexIDs: []int{1, 2}}
+			}
+
+			imp := Import{ID: uuid.New(), Source: source, Mode: ImportModeFull, Targets: targets}
+			run := newRunningImport(imp.ID, func(error) {})
+
+			// when: the import runs and fails
+			err := service.importCatalog(t.Context(), imp, run)
+			testastic.Error(t, err)
+
+			service.failImport(t.Context(), imp.ID, err)
+
+			// then: the failure names the phase along with the error
+			failures := queue.failed()
+			testastic.Len(t, failures, 1)
+			testastic.Equal(t, tt.wantPhase, failures[0].Phase)
+			testastic.Contains(t, failures[0].Message, tt.wantMessage)
+		})
+	}
+}
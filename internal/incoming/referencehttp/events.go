@@ -0,0 +1,122 @@
+package referencehttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reference-service-go/internal/core/pokemon"
+	"time"
+
+	"github.com/monkescience/vital"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	eventStreamMediaType = "text/event-stream"
+
+	importEventStatus   = "status"
+	importEventProgress = "progress"
+
+	// eventKeepAliveInterval keeps idle streams open behind proxies.
+	eventKeepAliveInterval = 15 * time.Second
+)
+
+// StreamImportEvents streams the progress of an import as Server-Sent Events
+// until the import finished or the client went away.
+func (h *APIHandler) StreamImportEvents(w http.ResponseWriter, r *http.Request, importID openapi_types.UUID) {
+	events, err := h.pokemonService.WatchImport(r.Context(), importID)
+	if err != nil {
+		if errors.Is(err, pokemon.ErrImportNotFound) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("import %s not found", importID),
+			))
+
+			return
+		}
+
+		slog.ErrorContext(r.Context(), "failed to watch import", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to watch import"))
+
+		return
+	}
+
+	controller := http.NewResponseController(w)
+
+	// The stream lives as long as the import, well beyond the server's write timeout.
+	err = controller.SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.WarnContext(r.Context(), "failed to clear write deadline", slog.Any("error", err))
+	}
+
+	w.Header().Set("Content-Type", eventStreamMediaType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	var status pokemon.ImportStatus
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			name := importEventProgress
+			if event.Status != status {
+				name = importEventStatus
+			}
+
+			status = event.Status
+
+			err = writeEvent(w, name, importEventToResponse(event))
+		case <-keepAlive.C:
+			_, err = io.WriteString(w, ": keep-alive\n\n")
+		}
+
+		if err == nil {
+			err = controller.Flush()
+		}
+
+		// The client went away, which also ends the watch with the request context.
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes a named Server-Sent Event with JSON data.
+func writeEvent(w io.Writer, name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", name, err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload)
+	if err != nil {
+		return fmt.Errorf("writing %s event: %w", name, err)
+	}
+
+	return nil
+}
+
+func importEventToResponse(event pokemon.ImportEvent) ImportEvent {
+	response := ImportEvent{
+		ImportId:       event.ImportID,
+		Status:         ImportEventStatus(event.Status),
+		FetchedCount:   event.Fetched,
+		PersistedCount: event.Persisted,
+		FailedCount:    event.Failed,
+	}
+
+	if event.Expected > 0 {
+		response.ExpectedCount = &event.Expected
+	}
+
+	return response
+}
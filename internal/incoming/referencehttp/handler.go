@@ -6,9 +6,13 @@
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"mime"
 	"net/http"
 	"reference-service-go/internal/core/catch"
 	"reference-service-go/internal/core/pokemon"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/monkescience/vital"
@@ -21,14 +25,45 @@
 	maxLimit      = 100
 	maxInt32      = int(^uint32(0) >> 1)
 	minInt32      = -maxInt32 - 1
+
+	// maxImportPokedexIDs is the most Pokedex IDs one import may list.
+	maxImportPokedexIDs = 1000
+
+	percentScale = 100
+	// percentPrecision rounds percentages to one decimal place.
+	percentPrecision = 10
+)
+
+var (
+	errInvalidBody       = errors.New("invalid request body")
+	errSourceRequired    = errors.New("source is required")
+	errUnsupportedSource = errors.New("unsupported source")
+	errUnsupportedMode   = errors.New("unsupported mode")
+	errTargetsConflict   = errors.New("pokedex_ids cannot be combined with from or to")
+	errNoPokedexIDs      = errors.New("pokedex_ids must not be empty")
+	errTooManyPokedexIDs = errors.New("pokedex_ids must not list more than 1000 ids")
+	errPokedexIDRange    = errors.New("pokedex ids must be between 1 and 2147483647")
+	errInvertedRange     = errors.New("from must not be greater than to")
 )
 
 // PokemonService defines the Pokemon operations the handler needs.
 type PokemonService interface {
-	CreateImport(ctx context.Context, source string) (*pokemon.Import, error)
+	CreateImport(ctx context.Context, params pokemon.CreateImportParams) (*pokemon.Import, error)
 	GetImport(ctx context.Context, id uuid.UUID) (*pokemon.Import, error)
+	CancelImport(ctx context.Context, id uuid.UUID) (*pokemon.Import, error)
+	RetryImport(ctx context.Context, id uuid.UUID) (*pokemon.Import, error)
+	WatchImport(ctx context.Context, id uuid.UUID) (<-chan pokemon.ImportEvent, error)
 	GetPokemonByID(ctx context.Context, pokedexID int) (*pokemon.Pokemon, error)
+	ListImports(ctx context.Context, params pokemon.ImportListParams) ([]pokemon.Import, int64, error)
+	ListImportErrors(ctx context.Context, id uuid.UUID, limit, offset int) ([]pokemon.ItemError, int64, error)
+	GetImportDiff(
+		ctx context.Context,
+		id uuid.UUID,
+		kind *pokemon.DiffKind,
+		limit, offset int,
+	) ([]pokemon.SpeciesDiff, pokemon.DiffSummary, error)
 	ListPokemon(ctx context.Context, params pokemon.ListParams) ([]pokemon.Pokemon, int64, error)
+	GetPokemonHistory(ctx context.Context, pokedexID, limit, offset int) ([]pokemon.PokemonChange, int64, error)
 }
 
 // CatchService defines the catch operations the handler needs.
@@ -53,48 +88,94 @@ func NewHandler(pokemonService PokemonService, catchService CatchService) *APIHa
 	}
 }
 
-// CreateImport creates a new import job.
-func (h *APIHandler) CreateImport(w http.ResponseWriter, r *http.Request) {
-	var req CreateImportRequest
+// ListImports lists imports, newest first.
+func (h *APIHandler) ListImports(w http.ResponseWriter, r *http.Request, params ListImportsParams) {
+	limit, offset := pagination(params.Limit, params.Offset)
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		vital.RespondProblem(r.Context(), w, vital.BadRequest("invalid request body"))
+	listParams := pokemon.ImportListParams{Limit: limit, Offset: offset}
 
-		return
+	if params.Status != nil {
+		if !params.Status.Valid() {
+			vital.RespondProblem(r.Context(), w, vital.BadRequest(fmt.Sprintf("unsupported status %q", *params.Status)))
+
+			return
+		}
+
+		status := pokemon.ImportStatus(*params.Status)
+		listParams.Filter.Status = &status
 	}
 
-	if req.Source == "" {
-		vital.RespondProblem(r.Context(), w, vital.BadRequest("source is required"))
+	listParams.Filter.Source = params.Source
 
-		return
+	if params.CreatedAfter != nil {
+		listParams.Filter.CreatedAfter = *params.CreatedAfter
+	}
+
+	if params.CreatedBefore != nil {
+		listParams.Filter.CreatedBefore = *params.CreatedBefore
 	}
 
-	if req.Source != CreateImportRequestSourcePokeapi {
-		vital.RespondProblem(r.Context(), w, vital.BadRequest(fmt.Sprintf("unsupported source %q", req.Source)))
+	if params.CreatedAfter != nil && params.CreatedBefore != nil && !params.CreatedAfter.Before(*params.CreatedBefore) {
+		vital.RespondProblem(r.Context(), w, vital.BadRequest("created_after must be before created_before"))
 
 		return
 	}
 
-	imp, err := h.pokemonService.CreateImport(r.Context(), string(req.Source))
+	items, total, err := h.pokemonService.ListImports(r.Context(), listParams)
 	if err != nil {
-		slog.ErrorContext(r.Context(), "failed to create import", slog.Any("error", err))
-		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to create import"))
+		slog.ErrorContext(r.Context(), "failed to list imports", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to list imports"))
 
 		return
 	}
 
-	resp := ImportResponse{
-		Id:        imp.ID,
-		Source:    ImportResponseSource(imp.Source),
-		Status:    ImportResponseStatus(imp.Status),
-		ItemCount: imp.ItemCount,
-		CreatedAt: imp.CreatedAt,
-		UpdatedAt: imp.UpdatedAt,
+	responses := make([]ImportResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, importToResponse(item))
 	}
 
-	w.Header().Set("Location", "/imports/"+imp.ID.String())
-	respondJSON(r.Context(), w, http.StatusCreated, resp)
+	respondJSON(r.Context(), w, http.StatusOK, ImportListResponse{
+		Items:  responses,
+		Total:  int(total),
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// CreateImport creates a new import job from a JSON request or an NDJSON or CSV upload.
+func (h *APIHandler) CreateImport(w http.ResponseWriter, r *http.Request, params CreateImportParams) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	var (
+		importParams pokemon.CreateImportParams
+		err          error
+	)
+
+	switch mediaType {
+	case multipartMediaType, ndjsonMediaType, ndjsonAltMediaType, csvMediaType:
+		importParams, err = uploadImportParams(w, r, mediaType, params)
+	default:
+		importParams, err = jsonImportParams(r)
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		vital.RespondProblem(r.Context(), w, &vital.ProblemDetail{
+			Title:  "Upload Too Large",
+			Status: http.StatusRequestEntityTooLarge,
+			Detail: fmt.Sprintf("uploads must not exceed %d bytes", maxBytesErr.Limit),
+		})
+
+		return
+	}
+
+	if err != nil {
+		vital.RespondProblem(r.Context(), w, vital.BadRequest(err.Error()))
+
+		return
+	}
+
+	h.createImport(w, r, importParams, params.Join != nil && *params.Join)
 }
 
 // GetImport returns the state of an import by ID.
@@ -115,13 +196,187 @@ func (h *APIHandler) GetImport(w http.ResponseWriter, r *http.Request, importID
 		return
 	}
 
-	respondJSON(r.Context(), w, http.StatusOK, ImportResponse{
-		Id:        imp.ID,
-		Source:    ImportResponseSource(imp.Source),
-		Status:    ImportResponseStatus(imp.Status),
-		ItemCount: imp.ItemCount,
-		CreatedAt: imp.CreatedAt,
-		UpdatedAt: imp.UpdatedAt,
+	respondJSON(r.Context(), w, http.StatusOK, importToResponse(*imp))
+}
+
+// CancelImport cancels a pending or processing import.
+func (h *APIHandler) CancelImport(w http.ResponseWriter, r *http.Request, importID openapi_types.UUID) {
+	imp, err := h.pokemonService.CancelImport(r.Context(), importID)
+	if err != nil {
+		if errors.Is(err, pokemon.ErrImportNotFound) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("import %s not found", importID),
+			))
+
+			return
+		}
+
+		if errors.Is(err, pokemon.ErrImportFinished) {
+			vital.RespondProblem(r.Context(), w, &vital.ProblemDetail{
+				Title:  "Import Already Finished",
+				Status: http.StatusConflict,
+				Detail: fmt.Sprintf("import %s has already finished and cannot be cancelled", importID),
+			})
+
+			return
+		}
+
+		slog.ErrorContext(r.Context(), "failed to cancel import", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to cancel import"))
+
+		return
+	}
+
+	respondJSON(r.Context(), w, http.StatusOK, importToResponse(*imp))
+}
+
+// RetryImport creates a child import for the failed items of an import.
+func (h *APIHandler) RetryImport(w http.ResponseWriter, r *http.Request, importID openapi_types.UUID) {
+	imp, err := h.pokemonService.RetryImport(r.Context(), importID)
+	if err != nil {
+		if errors.Is(err, pokemon.ErrImportNotFound) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("import %s not found", importID),
+			))
+
+			return
+		}
+
+		if errors.Is(err, pokemon.ErrImportRunning) {
+			vital.RespondProblem(r.Context(), w, &vital.ProblemDetail{
+				Title:  "Import Still Running",
+				Status: http.StatusConflict,
+				Detail: fmt.Sprintf("import %s is still running and cannot be retried yet", importID),
+			})
+
+			return
+		}
+
+		if errors.Is(err, pokemon.ErrImportInFlight) {
+			respondImportInFlight(r.Context(), w, err)
+
+			return
+		}
+
+		if errors.Is(err, pokemon.ErrNothingToRetry) {
+			vital.RespondProblem(r.Context(), w, &vital.ProblemDetail{
+				Title:  "Nothing To Retry",
+				Status: http.StatusConflict,
+				Detail: fmt.Sprintf("import %s has no failed or unreached items", importID),
+			})
+
+			return
+		}
+
+		slog.ErrorContext(r.Context(), "failed to retry import", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to retry import"))
+
+		return
+	}
+
+	w.Header().Set("Location", "/imports/"+imp.ID.String())
+	respondJSON(r.Context(), w, http.StatusCreated, importToResponse(*imp))
+}
+
+// ListImportErrors lists the species an import skipped.
+func (h *APIHandler) ListImportErrors(
+	w http.ResponseWriter,
+	r *http.Request,
+	importID openapi_types.UUID,
+	params ListImportErrorsParams,
+) {
+	limit, offset := pagination(params.Limit, params.Offset)
+
+	items, total, err := h.pokemonService.ListImportErrors(r.Context(), importID, limit, offset)
+	if err != nil {
+		if errors.Is(err, pokemon.ErrImportNotFound) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("import %s not found", importID),
+			))
+
+			return
+		}
+
+		slog.ErrorContext(r.Context(), "failed to list import errors", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to list import errors"))
+
+		return
+	}
+
+	responses := make([]ImportError, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, itemErrorToResponse(item))
+	}
+
+	respondJSON(r.Context(), w, http.StatusOK, ImportErrorListResponse{
+		Items:  responses,
+		Total:  int(total),
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// GetImportDiff returns the diff report of a dry-run import.
+func (h *APIHandler) GetImportDiff(
+	w http.ResponseWriter,
+	r *http.Request,
+	importID openapi_types.UUID,
+	params GetImportDiffParams,
+) {
+	limit, offset := pagination(params.Limit, params.Offset)
+
+	var kind *pokemon.DiffKind
+
+	if params.Kind != nil {
+		if !params.Kind.Valid() {
+			vital.RespondProblem(r.Context(), w, vital.BadRequest(fmt.Sprintf("unsupported kind %q", *params.Kind)))
+
+			return
+		}
+
+		diffKind := pokemon.DiffKind(*params.Kind)
+		kind = &diffKind
+	}
+
+	items, summary, err := h.pokemonService.GetImportDiff(r.Context(), importID, kind, limit, offset)
+	if err != nil {
+		if errors.Is(err, pokemon.ErrImportNotFound) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("import %s not found", importID),
+			))
+
+			return
+		}
+
+		if errors.Is(err, pokemon.ErrNotDryRun) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("import %s is not a dry run and has no diff report", importID),
+			))
+
+			return
+		}
+
+		slog.ErrorContext(r.Context(), "failed to get import diff", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to get import diff"))
+
+		return
+	}
+
+	responses := make([]SpeciesDiff, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, speciesDiffToResponse(item))
+	}
+
+	respondJSON(r.Context(), w, http.StatusOK, ImportDiffResponse{
+		Summary: ImportDiffSummary{
+			New:     summary.New,
+			Removed: summary.Removed,
+			Changed: summary.Changed,
+		},
+		Items:  responses,
+		Total:  summary.Count(kind),
+		Limit:  limit,
+		Offset: offset,
 	})
 }
 
@@ -195,31 +450,7 @@ func (h *APIHandler) GetCatch(w http.ResponseWriter, r *http.Request, catchID op
 
 // ListPokemon lists imported Pokemon.
 func (h *APIHandler) ListPokemon(w http.ResponseWriter, r *http.Request, params ListPokemonParams) {
-	limit := defaultLimit
-	if params.Limit != nil {
-		limit = *params.Limit
-	}
-
-	if limit < 1 {
-		limit = defaultLimit
-	}
-
-	if limit > maxLimit {
-		limit = maxLimit
-	}
-
-	offset := defaultOffset
-	if params.Offset != nil {
-		offset = *params.Offset
-	}
-
-	if offset < 0 {
-		offset = defaultOffset
-	}
-
-	if offset > maxInt32 {
-		offset = maxInt32
-	}
+	limit, offset := pagination(params.Limit, params.Offset)
 
 	listParams := pokemon.ListParams{Limit: limit, Offset: offset}
 
@@ -228,6 +459,10 @@ func (h *APIHandler) ListPokemon(w http.ResponseWriter, r *http.Request, params
 		listParams.Rarity = &rarity
 	}
 
+	if params.IncludeInactive != nil {
+		listParams.IncludeInactive = *params.IncludeInactive
+	}
+
 	items, total, err := h.pokemonService.ListPokemon(r.Context(), listParams)
 	if err != nil {
 		slog.ErrorContext(r.Context(), "failed to list pokemon", slog.Any("error", err))
@@ -276,6 +511,321 @@ func (h *APIHandler) GetPokemon(w http.ResponseWriter, r *http.Request, pokedexI
 	respondJSON(r.Context(), w, http.StatusOK, pokemonToSummary(*pokemonEntity))
 }
 
+// GetPokemonHistory lists the changes imports made to a Pokemon.
+func (h *APIHandler) GetPokemonHistory(
+	w http.ResponseWriter,
+	r *http.Request,
+	pokedexID int,
+	params GetPokemonHistoryParams,
+) {
+	if pokedexID < 0 || pokedexID > maxInt32 {
+		vital.RespondProblem(r.Context(), w, vital.BadRequest("pokedex_id is out of range"))
+
+		return
+	}
+
+	limit, offset := pagination(params.Limit, params.Offset)
+
+	items, total, err := h.pokemonService.GetPokemonHistory(r.Context(), pokedexID, limit, offset)
+	if err != nil {
+		if errors.Is(err, pokemon.ErrPokemonNotFound) {
+			vital.RespondProblem(r.Context(), w, vital.NotFound(
+				fmt.Sprintf("pokemon %d not found", pokedexID),
+			))
+
+			return
+		}
+
+		slog.ErrorContext(r.Context(), "failed to get pokemon history", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to get pokemon history"))
+
+		return
+	}
+
+	responses := make([]PokemonChange, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, PokemonChange{
+			ImportId:  item.ImportID,
+			ChangedAt: item.ChangedAt,
+			Changes:   fieldChangesToResponse(item.Changes),
+		})
+	}
+
+	respondJSON(r.Context(), w, http.StatusOK, PokemonHistoryResponse{
+		Items:  responses,
+		Total:  int(total),
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// createImport queues an import and responds with it. With join set, an import
+// of the same source that is already in flight is returned instead.
+func (h *APIHandler) createImport(
+	w http.ResponseWriter,
+	r *http.Request,
+	params pokemon.CreateImportParams,
+	join bool,
+) {
+	imp, err := h.pokemonService.CreateImport(r.Context(), params)
+
+	var inFlight *pokemon.ImportInFlightError
+	if join && errors.As(err, &inFlight) {
+		w.Header().Set("Location", "/imports/"+inFlight.Active.ID.String())
+		respondJSON(r.Context(), w, http.StatusOK, importToResponse(inFlight.Active))
+
+		return
+	}
+
+	if errors.Is(err, pokemon.ErrImportInFlight) {
+		respondImportInFlight(r.Context(), w, err)
+
+		return
+	}
+
+	if errors.Is(err, pokemon.ErrUnknownSource) {
+		vital.RespondProblem(r.Context(), w, vital.BadRequest(
+			fmt.Errorf("%w %q", errUnsupportedSource, params.Source).Error(),
+		))
+
+		return
+	}
+
+	if errors.Is(err, pokemon.ErrUploadRequired) {
+		vital.RespondProblem(r.Context(), w, vital.BadRequest(err.Error()))
+
+		return
+	}
+
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to create import", slog.Any("error", err))
+		vital.RespondProblem(r.Context(), w, vital.InternalServerError("failed to create import"))
+
+		return
+	}
+
+	w.Header().Set("Location", "/imports/"+imp.ID.String())
+	respondJSON(r.Context(), w, http.StatusCreated, importToResponse(*imp))
+}
+
+// pagination applies defaults and bounds to optional limit and offset parameters.
+func pagination(limitParam, offsetParam *int) (int, int) {
+	limit := defaultLimit
+	if limitParam != nil {
+		limit = *limitParam
+	}
+
+	if limit < 1 {
+		limit = defaultLimit
+	}
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := defaultOffset
+	if offsetParam != nil {
+		offset = *offsetParam
+	}
+
+	if offset < 0 {
+		offset = defaultOffset
+	}
+
+	if offset > maxInt32 {
+		offset = maxInt32
+	}
+
+	return limit, offset
+}
+
+// jsonImportParams reads the options of an import from a configured source from a JSON request.
+func jsonImportParams(r *http.Request) (pokemon.CreateImportParams, error) {
+	var req CreateImportRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return pokemon.CreateImportParams{}, errInvalidBody
+	}
+
+	if req.Source == "" {
+		return pokemon.CreateImportParams{}, errSourceRequired
+	}
+
+	params := pokemon.CreateImportParams{Source: req.Source, Mode: pokemon.ImportModeFull}
+
+	if req.Mode != nil {
+		if !req.Mode.Valid() {
+			return pokemon.CreateImportParams{}, fmt.Errorf("%w %q", errUnsupportedMode, *req.Mode)
+		}
+
+		params.Mode = pokemon.ImportMode(*req.Mode)
+	}
+
+	params.Targets, err = importTargets(req)
+	if err != nil {
+		return pokemon.CreateImportParams{}, err
+	}
+
+	return params, nil
+}
+
+// importTargets validates the Pokedex IDs or range of a create import request.
+// A request without either targets every species.
+func importTargets(req CreateImportRequest) (pokemon.ImportTargets, error) {
+	if req.PokedexIds != nil {
+		if req.From != nil || req.To != nil {
+			return pokemon.ImportTargets{}, errTargetsConflict
+		}
+
+		if len(*req.PokedexIds) == 0 {
+			return pokemon.ImportTargets{}, errNoPokedexIDs
+		}
+
+		if len(*req.PokedexIds) > maxImportPokedexIDs {
+			return pokemon.ImportTargets{}, fmt.Errorf("%w: got %d", errTooManyPokedexIDs, len(*req.PokedexIds))
+		}
+
+		for _, id := range *req.PokedexIds {
+			if id < 1 || id > maxInt32 {
+				return pokemon.ImportTargets{}, fmt.Errorf("%w: got %d", errPokedexIDRange, id)
+			}
+		}
+
+		return pokemon.ImportTargets{PokedexIDs: slices.Clone(*req.PokedexIds)}, nil
+	}
+
+	targets := pokemon.AllSpecies()
+
+	if req.From != nil {
+		if *req.From < 1 || *req.From > maxInt32 {
+			return pokemon.ImportTargets{}, fmt.Errorf("%w: got from %d", errPokedexIDRange, *req.From)
+		}
+
+		targets.From = *req.From
+	}
+
+	if req.To != nil {
+		if *req.To < 1 || *req.To > maxInt32 {
+			return pokemon.ImportTargets{}, fmt.Errorf("%w: got to %d", errPokedexIDRange, *req.To)
+		}
+
+		if *req.To < targets.From {
+			return pokemon.ImportTargets{}, errInvertedRange
+		}
+
+		targets.To = *req.To
+	}
+
+	return targets, nil
+}
+
+func importToResponse(imp pokemon.Import) ImportResponse {
+	resp := ImportResponse{
+		Id:             imp.ID,
+		Source:         imp.Source,
+		Status:         ImportResponseStatus(imp.Status),
+		Mode:           ImportResponseMode(imp.Mode),
+		ItemCount:      imp.ItemCount,
+		InsertedCount:  imp.Upserts.Inserted,
+		UpdatedCount:   imp.Upserts.Updated,
+		UnchangedCount: imp.Upserts.Unchanged,
+		FailedCount:    imp.FailedCount,
+		CreatedAt:      imp.CreatedAt,
+		UpdatedAt:      imp.UpdatedAt,
+	}
+
+	if imp.ParentID != nil {
+		parentID := *imp.ParentID
+		resp.ParentImportId = &parentID
+	}
+
+	if len(imp.Targets.PokedexIDs) > 0 {
+		pokedexIDs := slices.Clone(imp.Targets.PokedexIDs)
+		resp.PokedexIds = &pokedexIDs
+	}
+
+	if imp.Targets.From > 0 {
+		from := imp.Targets.From
+		resp.From = &from
+	}
+
+	if imp.Targets.To > 0 {
+		to := imp.Targets.To
+		resp.To = &to
+	}
+
+	if imp.Failure != nil {
+		resp.Failure = &ImportFailure{
+			Phase:          ImportFailurePhase(imp.Failure.Phase),
+			Message:        imp.Failure.Message,
+			PersistedCount: imp.Failure.PersistedCount,
+		}
+	}
+
+	setImportTiming(&resp, imp, time.Now())
+
+	return resp
+}
+
+// setImportTiming fills in when an import ran and how far along it is.
+func setImportTiming(resp *ImportResponse, imp pokemon.Import, now time.Time) {
+	resp.StartedAt = imp.StartedAt
+	resp.FinishedAt = imp.FinishedAt
+
+	if completion, ok := imp.Completion(); ok {
+		expected := imp.ExpectedCount
+		percent := math.Round(completion*percentScale*percentPrecision) / percentPrecision
+
+		resp.ExpectedCount = &expected
+		resp.PercentComplete = &percent
+	}
+
+	if remaining, ok := imp.EstimatedRemaining(now); ok {
+		seconds := int(remaining.Round(time.Second).Seconds())
+		resp.EstimatedSecondsRemaining = &seconds
+	}
+}
+
+func itemErrorToResponse(itemErr pokemon.ItemError) ImportError {
+	resp := ImportError{
+		PokedexId:  itemErr.PokedexID,
+		ErrorClass: ImportErrorErrorClass(itemErr.Class),
+		Message:    itemErr.Message,
+		CreatedAt:  itemErr.CreatedAt,
+	}
+
+	if itemErr.HTTPStatus != 0 {
+		resp.HttpStatus = &itemErr.HTTPStatus
+	}
+
+	return resp
+}
+
+func speciesDiffToResponse(diff pokemon.SpeciesDiff) SpeciesDiff {
+	resp := SpeciesDiff{
+		PokedexId: diff.PokedexID,
+		Name:      diff.Name,
+		Kind:      SpeciesDiffKind(diff.Kind),
+	}
+
+	if len(diff.Changes) > 0 {
+		changes := fieldChangesToResponse(diff.Changes)
+		resp.Changes = &changes
+	}
+
+	return resp
+}
+
+func fieldChangesToResponse(changes []pokemon.FieldChange) []FieldChange {
+	resp := make([]FieldChange, 0, len(changes))
+	for _, change := range changes {
+		resp = append(resp, FieldChange{Field: change.Field, Old: change.Old, New: change.New})
+	}
+
+	return resp
+}
+
 func pokemonToSummary(p pokemon.Pokemon) PokemonSummary {
 	return PokemonSummary{
 		Id:        p.PokedexID,
@@ -291,7 +841,27 @@ func pokemonToSummary(p pokemon.Pokemon) PokemonSummary {
 			SpecialDefense: p.SpecialDefense,
 			Speed:          p.Speed,
 		},
+		DeletedAt: p.DeletedAt,
+	}
+}
+
+// respondImportInFlight rejects an import because another import of the same
+// source is in flight and links to that import when it is known.
+func respondImportInFlight(ctx context.Context, w http.ResponseWriter, err error) {
+	detail := "another import of the same source is in flight"
+
+	var inFlight *pokemon.ImportInFlightError
+	if errors.As(err, &inFlight) {
+		w.Header().Set("Location", "/imports/"+inFlight.Active.ID.String())
+
+		detail = fmt.Sprintf("import %s of source %q is in flight", inFlight.Active.ID, inFlight.Active.Source)
 	}
+
+	vital.RespondProblem(ctx, w, &vital.ProblemDetail{
+		Title:  "Import In Flight",
+		Status: http.StatusConflict,
+		Detail: detail,
+	})
 }
 
 func respondJSON(ctx context.Context, w http.ResponseWriter, status int, body any) {
@@ -6,9 +6,7 @@
 import (
 	"bytes"
 	"compress/gzip"
-	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -19,7 +17,6 @@
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
-	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
@@ -71,30 +68,120 @@ func (e CreateCatchRequestPokeballType) Valid() bool {
 	}
 }
 
-// Defines values for CreateImportRequestSource.
+// Defines values for CreateImportRequestMode.
 const (
-	CreateImportRequestSourcePokeapi CreateImportRequestSource = "pokeapi"
+	CreateImportRequestModeDryRun      CreateImportRequestMode = "dry_run"
+	CreateImportRequestModeFull        CreateImportRequestMode = "full"
+	CreateImportRequestModeIncremental CreateImportRequestMode = "incremental"
 )
 
-// Valid indicates whether the value is a known member of the CreateImportRequestSource enum.
-func (e CreateImportRequestSource) Valid() bool {
+// Valid indicates whether the value is a known member of the CreateImportRequestMode enum.
+func (e CreateImportRequestMode) Valid() bool {
 	switch e {
-	case CreateImportRequestSourcePokeapi:
+	case CreateImportRequestModeDryRun:
+		return true
+	case CreateImportRequestModeFull:
+		return true
+	case CreateImportRequestModeIncremental:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ImportErrorErrorClass.
+const (
+	HttpStatus ImportErrorErrorClass = "http_status"
+	Mapping    ImportErrorErrorClass = "mapping"
+	Timeout    ImportErrorErrorClass = "timeout"
+	Transport  ImportErrorErrorClass = "transport"
+)
+
+// Valid indicates whether the value is a known member of the ImportErrorErrorClass enum.
+func (e ImportErrorErrorClass) Valid() bool {
+	switch e {
+	case HttpStatus:
+		return true
+	case Mapping:
+		return true
+	case Timeout:
+		return true
+	case Transport:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ImportEventStatus.
+const (
+	ImportEventStatusCancelled   ImportEventStatus = "cancelled"
+	ImportEventStatusCompleted   ImportEventStatus = "completed"
+	ImportEventStatusFailed      ImportEventStatus = "failed"
+	ImportEventStatusInterrupted ImportEventStatus = "interrupted"
+	ImportEventStatusPending     ImportEventStatus = "pending"
+	ImportEventStatusProcessing  ImportEventStatus = "processing"
+)
+
+// Valid indicates whether the value is a known member of the ImportEventStatus enum.
+func (e ImportEventStatus) Valid() bool {
+	switch e {
+	case ImportEventStatusCancelled:
+		return true
+	case ImportEventStatusCompleted:
+		return true
+	case ImportEventStatusFailed:
+		return true
+	case ImportEventStatusInterrupted:
+		return true
+	case ImportEventStatusPending:
+		return true
+	case ImportEventStatusProcessing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ImportFailurePhase.
+const (
+	Fetch        ImportFailurePhase = "fetch"
+	Recovery     ImportFailurePhase = "recovery"
+	SpeciesCount ImportFailurePhase = "species_count"
+	Upsert       ImportFailurePhase = "upsert"
+)
+
+// Valid indicates whether the value is a known member of the ImportFailurePhase enum.
+func (e ImportFailurePhase) Valid() bool {
+	switch e {
+	case Fetch:
+		return true
+	case Recovery:
+		return true
+	case SpeciesCount:
+		return true
+	case Upsert:
 		return true
 	default:
 		return false
 	}
 }
 
-// Defines values for ImportResponseSource.
+// Defines values for ImportResponseMode.
 const (
-	ImportResponseSourcePokeapi ImportResponseSource = "pokeapi"
+	ImportResponseModeDryRun      ImportResponseMode = "dry_run"
+	ImportResponseModeFull        ImportResponseMode = "full"
+	ImportResponseModeIncremental ImportResponseMode = "incremental"
 )
 
-// Valid indicates whether the value is a known member of the ImportResponseSource enum.
-func (e ImportResponseSource) Valid() bool {
+// Valid indicates whether the value is a known member of the ImportResponseMode enum.
+func (e ImportResponseMode) Valid() bool {
 	switch e {
-	case ImportResponseSourcePokeapi:
+	case ImportResponseModeDryRun:
+		return true
+	case ImportResponseModeFull:
+		return true
+	case ImportResponseModeIncremental:
 		return true
 	default:
 		return false
@@ -103,22 +190,28 @@ func (e ImportResponseSource) Valid() bool {
 
 // Defines values for ImportResponseStatus.
 const (
-	Completed  ImportResponseStatus = "completed"
-	Failed     ImportResponseStatus = "failed"
-	Pending    ImportResponseStatus = "pending"
-	Processing ImportResponseStatus = "processing"
+	ImportResponseStatusCancelled   ImportResponseStatus = "cancelled"
+	ImportResponseStatusCompleted   ImportResponseStatus = "completed"
+	ImportResponseStatusFailed      ImportResponseStatus = "failed"
+	ImportResponseStatusInterrupted ImportResponseStatus = "interrupted"
+	ImportResponseStatusPending     ImportResponseStatus = "pending"
+	ImportResponseStatusProcessing  ImportResponseStatus = "processing"
 )
 
 // Valid indicates whether the value is a known member of the ImportResponseStatus enum.
 func (e ImportResponseStatus) Valid() bool {
 	switch e {
-	case Completed:
+	case ImportResponseStatusCancelled:
 		return true
-	case Failed:
+	case ImportResponseStatusCompleted:
 		return true
-	case Pending:
+	case ImportResponseStatusFailed:
 		return true
-	case Processing:
+	case ImportResponseStatusInterrupted:
+		return true
+	case ImportResponseStatusPending:
+		return true
+	case ImportResponseStatusProcessing:
 		return true
 	default:
 		return false
@@ -152,6 +245,99 @@ func (e PokemonSummaryRarity) Valid() bool {
 	}
 }
 
+// Defines values for SpeciesDiffKind.
+const (
+	SpeciesDiffKindChanged SpeciesDiffKind = "changed"
+	SpeciesDiffKindNew     SpeciesDiffKind = "new"
+	SpeciesDiffKindRemoved SpeciesDiffKind = "removed"
+)
+
+// Valid indicates whether the value is a known member of the SpeciesDiffKind enum.
+func (e SpeciesDiffKind) Valid() bool {
+	switch e {
+	case SpeciesDiffKindChanged:
+		return true
+	case SpeciesDiffKindNew:
+		return true
+	case SpeciesDiffKindRemoved:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ListImportsParamsStatus.
+const (
+	Cancelled   ListImportsParamsStatus = "cancelled"
+	Completed   ListImportsParamsStatus = "completed"
+	Failed      ListImportsParamsStatus = "failed"
+	Interrupted ListImportsParamsStatus = "interrupted"
+	Pending     ListImportsParamsStatus = "pending"
+	Processing  ListImportsParamsStatus = "processing"
+)
+
+// Valid indicates whether the value is a known member of the ListImportsParamsStatus enum.
+func (e ListImportsParamsStatus) Valid() bool {
+	switch e {
+	case Cancelled:
+		return true
+	case Completed:
+		return true
+	case Failed:
+		return true
+	case Interrupted:
+		return true
+	case Pending:
+		return true
+	case Processing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CreateImportParamsMode.
+const (
+	DryRun      CreateImportParamsMode = "dry_run"
+	Full        CreateImportParamsMode = "full"
+	Incremental CreateImportParamsMode = "incremental"
+)
+
+// Valid indicates whether the value is a known member of the CreateImportParamsMode enum.
+func (e CreateImportParamsMode) Valid() bool {
+	switch e {
+	case DryRun:
+		return true
+	case Full:
+		return true
+	case Incremental:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetImportDiffParamsKind.
+const (
+	GetImportDiffParamsKindChanged GetImportDiffParamsKind = "changed"
+	GetImportDiffParamsKindNew     GetImportDiffParamsKind = "new"
+	GetImportDiffParamsKindRemoved GetImportDiffParamsKind = "removed"
+)
+
+// Valid indicates whether the value is a known member of the GetImportDiffParamsKind enum.
+func (e GetImportDiffParamsKind) Valid() bool {
+	switch e {
+	case GetImportDiffParamsKindChanged:
+		return true
+	case GetImportDiffParamsKindNew:
+		return true
+	case GetImportDiffParamsKindRemoved:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for ListPokemonParamsRarity.
 const (
 	ListPokemonParamsRarityCommon    ListPokemonParamsRarity = "common"
@@ -205,42 +391,255 @@ type CreateCatchRequest struct {
 // CreateCatchRequestPokeballType Type of Pokeball to open
 type CreateCatchRequestPokeballType string
 
+// CreateFileImportRequest defines model for create_file_import_request.
+type CreateFileImportRequest struct {
+	// File NDJSON or CSV rows. The format follows the part's content type and
+	// falls back to the file extension (.ndjson, .jsonl or .csv).
+	File openapi_types.File `json:"file"`
+}
+
 // CreateImportRequest defines model for create_import_request.
 type CreateImportRequest struct {
-	// Source The data source to import from
-	Source CreateImportRequestSource `json:"source"`
+	// From First Pokedex ID of the range to import, defaults to 1
+	From *int `json:"from,omitempty"`
+
+	// Mode How fetched species are written. A full import rewrites every
+	// species, an incremental import skips species whose content did not
+	// change since they were last written. A dry run writes nothing and
+	// records how the fetched species differ from the catalog instead,
+	// see GET /imports/{import_id}/diff.
+	Mode *CreateImportRequestMode `json:"mode,omitempty"`
+
+	// PokedexIds Pokedex IDs to import. Cannot be combined with from or to. Without
+	// pokedex_ids, from or to every species is imported.
+	PokedexIds *[]int `json:"pokedex_ids,omitempty"`
+
+	// Source The name of a data source configured under `imports.sources`.
+	// Uploads use the built-in `file` source instead.
+	Source string `json:"source"`
+
+	// To Last Pokedex ID of the range to import, defaults to the last known species
+	To *int `json:"to,omitempty"`
+}
+
+// CreateImportRequestMode How fetched species are written. A full import rewrites every
+// species, an incremental import skips species whose content did not
+// change since they were last written. A dry run writes nothing and
+// records how the fetched species differ from the catalog instead,
+// see GET /imports/{import_id}/diff.
+type CreateImportRequestMode string
+
+// FieldChange defines model for field_change.
+type FieldChange struct {
+	// Field Name of the changed field, as in pokemon_detail
+	Field string `json:"field"`
+
+	// New Value the import fetched
+	New interface{} `json:"new"`
+
+	// Old Value in the catalog
+	Old interface{} `json:"old"`
+}
+
+// ImportDiffResponse defines model for import_diff_response.
+type ImportDiffResponse struct {
+	Items   []SpeciesDiff     `json:"items"`
+	Limit   int               `json:"limit"`
+	Offset  int               `json:"offset"`
+	Summary ImportDiffSummary `json:"summary"`
+
+	// Total Total number of species matching the kind filter
+	Total int `json:"total"`
+}
+
+// ImportDiffSummary defines model for import_diff_summary.
+type ImportDiffSummary struct {
+	// Changed Number of species with fields the import would overwrite
+	Changed int `json:"changed"`
+
+	// New Number of species the catalog does not have yet
+	New int `json:"new"`
+
+	// Removed Number of catalog species the source no longer knows about
+	Removed int `json:"removed"`
+}
+
+// ImportError defines model for import_error.
+type ImportError struct {
+	// CreatedAt When the error was recorded
+	CreatedAt time.Time `json:"created_at"`
+
+	// ErrorClass Why the species could not be imported
+	ErrorClass ImportErrorErrorClass `json:"error_class"`
+
+	// HttpStatus Upstream response status, set when error_class is http_status
+	HttpStatus *int `json:"http_status,omitempty"`
+
+	// Message Error message reported for the species
+	Message string `json:"message"`
+
+	// PokedexId National Pokedex number of the skipped species
+	PokedexId int `json:"pokedex_id"`
+}
+
+// ImportErrorErrorClass Why the species could not be imported
+type ImportErrorErrorClass string
+
+// ImportErrorListResponse defines model for import_error_list_response.
+type ImportErrorListResponse struct {
+	Items  []ImportError `json:"items"`
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+
+	// Total Total number of species the import skipped
+	Total int `json:"total"`
+}
+
+// ImportEvent Snapshot of an import's progress sent over the events stream
+type ImportEvent struct {
+	// ExpectedCount Number of species the import targets in total, when known
+	ExpectedCount *int `json:"expected_count,omitempty"`
+
+	// FailedCount Number of species skipped so far
+	FailedCount int `json:"failed_count"`
+
+	// FetchedCount Number of species fetched so far
+	FetchedCount int `json:"fetched_count"`
+
+	// ImportId Unique identifier of the import
+	ImportId openapi_types.UUID `json:"import_id"`
+
+	// PersistedCount Number of species written to the catalog so far
+	PersistedCount int `json:"persisted_count"`
+
+	// Status Current status of the import
+	Status ImportEventStatus `json:"status"`
+}
+
+// ImportEventStatus Current status of the import
+type ImportEventStatus string
+
+// ImportFailure Why and where a failed import broke off
+type ImportFailure struct {
+	// Message Error the import failed with
+	Message string `json:"message"`
+
+	// PersistedCount Number of items the import had written when it failed
+	PersistedCount int `json:"persisted_count"`
+
+	// Phase Phase the import failed in. species_count and fetch point at the source, upsert at the database, and recovery at workers that died while running the import too often.
+	Phase ImportFailurePhase `json:"phase"`
 }
 
-// CreateImportRequestSource The data source to import from
-type CreateImportRequestSource string
+// ImportFailurePhase Phase the import failed in. species_count and fetch point at the source, upsert at the database, and recovery at workers that died while running the import too often.
+type ImportFailurePhase string
+
+// ImportListResponse defines model for import_list_response.
+type ImportListResponse struct {
+	Items  []ImportResponse `json:"items"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+
+	// Total Total number of imports matching the query
+	Total int `json:"total"`
+}
 
 // ImportResponse defines model for import_response.
 type ImportResponse struct {
 	// CreatedAt Timestamp when the import was created
 	CreatedAt time.Time `json:"created_at"`
 
+	// EstimatedSecondsRemaining Estimated seconds until a processing import finishes, extrapolated from its pace
+	EstimatedSecondsRemaining *int `json:"estimated_seconds_remaining,omitempty"`
+
+	// ExpectedCount Number of species the import targets in total, set once a worker resolved its targets
+	ExpectedCount *int `json:"expected_count,omitempty"`
+
+	// FailedCount Number of items that could not be imported
+	FailedCount int `json:"failed_count"`
+
+	// Failure Why and where a failed import broke off
+	Failure *ImportFailure `json:"failure,omitempty"`
+
+	// FinishedAt Timestamp when the import completed, failed or was cancelled
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// From First Pokedex ID of the range the import fetches
+	From *int `json:"from,omitempty"`
+
 	// Id Unique identifier of the import
 	Id openapi_types.UUID `json:"id"`
 
+	// InsertedCount Number of imported items that were new to the catalog, or would be for a dry run
+	InsertedCount int `json:"inserted_count"`
+
 	// ItemCount Number of items imported so far
 	ItemCount int `json:"item_count"`
 
+	// Mode How fetched species are written
+	Mode ImportResponseMode `json:"mode"`
+
+	// ParentImportId The import this import retries
+	ParentImportId *openapi_types.UUID `json:"parent_import_id,omitempty"`
+
+	// PercentComplete Share of the expected species handled so far, from 0 to 100
+	PercentComplete *float64 `json:"percent_complete,omitempty"`
+
+	// PokedexIds Pokedex IDs the import fetches in addition to its range
+	PokedexIds *[]int `json:"pokedex_ids,omitempty"`
+
 	// Source The data source being imported from
-	Source ImportResponseSource `json:"source"`
+	Source string `json:"source"`
 
-	// Status Current status of the import
+	// StartedAt Timestamp when a worker first picked up the import
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Status Current status of the import. An interrupted import was stopped by
+	// a shutting down replica and is resumed from its checkpoint.
 	Status ImportResponseStatus `json:"status"`
 
+	// To Last Pokedex ID of the range the import fetches, unset for every known species
+	To *int `json:"to,omitempty"`
+
+	// UnchangedCount Number of imported items that were skipped because they did not change. A dry run counts the entries it found unchanged.
+	UnchangedCount int `json:"unchanged_count"`
+
 	// UpdatedAt Timestamp when the import was last updated
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// UpdatedCount Number of imported items that were written over an existing entry. A dry run counts the entries it would change.
+	UpdatedCount int `json:"updated_count"`
 }
 
-// ImportResponseSource The data source being imported from
-type ImportResponseSource string
+// ImportResponseMode How fetched species are written
+type ImportResponseMode string
 
-// ImportResponseStatus Current status of the import
+// ImportResponseStatus Current status of the import. An interrupted import was stopped by
+// a shutting down replica and is resumed from its checkpoint.
 type ImportResponseStatus string
 
+// PokemonChange defines model for pokemon_change.
+type PokemonChange struct {
+	// ChangedAt Timestamp when the import wrote the change
+	ChangedAt time.Time `json:"changed_at"`
+
+	// Changes Fields the import overwrote
+	Changes []FieldChange `json:"changes"`
+
+	// ImportId Import that changed the Pokemon
+	ImportId openapi_types.UUID `json:"import_id"`
+}
+
+// PokemonHistoryResponse defines model for pokemon_history_response.
+type PokemonHistoryResponse struct {
+	Items  []PokemonChange `json:"items"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+
+	// Total Total number of changes to the Pokemon
+	Total int `json:"total"`
+}
+
 // PokemonListResponse defines model for pokemon_list_response.
 type PokemonListResponse struct {
 	Items  []PokemonSummary `json:"items"`
@@ -263,6 +662,9 @@ type PokemonStats struct {
 
 // PokemonSummary defines model for pokemon_summary.
 type PokemonSummary struct {
+	// DeletedAt Timestamp when a full import no longer found the species upstream. Inactive species are not listed or caught by default, but stay available for existing catches.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
 	// Id National Pokedex number
 	Id int `json:"id"`
 
@@ -301,6 +703,84 @@ type ProblemDetail struct {
 	Type *string `json:"type,omitempty"`
 }
 
+// SpeciesDiff defines model for species_diff.
+type SpeciesDiff struct {
+	// Changes Fields the import would overwrite, set for changed species
+	Changes *[]FieldChange `json:"changes,omitempty"`
+
+	// Kind How the species differs from the catalog
+	Kind SpeciesDiffKind `json:"kind"`
+
+	// Name Name of the species, as fetched unless it was removed
+	Name string `json:"name"`
+
+	// PokedexId Pokedex ID of the species
+	PokedexId int `json:"pokedex_id"`
+}
+
+// SpeciesDiffKind How the species differs from the catalog
+type SpeciesDiffKind string
+
+// ListImportsParams defines parameters for ListImports.
+type ListImportsParams struct {
+	// Limit Number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Status Filter by import status
+	Status *ListImportsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Source Filter by data source
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+
+	// CreatedAfter Only return imports created at or after this time
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only return imports created before this time
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+}
+
+// ListImportsParamsStatus defines parameters for ListImports.
+type ListImportsParamsStatus string
+
+// CreateImportParams defines parameters for CreateImport.
+type CreateImportParams struct {
+	// Mode Import mode of an upload. JSON requests set the mode in the body instead.
+	Mode *CreateImportParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Join Return the in-flight import of the same source with 200 instead of rejecting the request. The in-flight import keeps its own mode and targets.
+	Join *bool `form:"join,omitempty" json:"join,omitempty"`
+}
+
+// CreateImportParamsMode defines parameters for CreateImport.
+type CreateImportParamsMode string
+
+// GetImportDiffParams defines parameters for GetImportDiff.
+type GetImportDiffParams struct {
+	// Kind Only list species with this kind of difference
+	Kind *GetImportDiffParamsKind `form:"kind,omitempty" json:"kind,omitempty"`
+
+	// Limit Number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetImportDiffParamsKind defines parameters for GetImportDiff.
+type GetImportDiffParamsKind string
+
+// ListImportErrorsParams defines parameters for ListImportErrors.
+type ListImportErrorsParams struct {
+	// Limit Number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // ListPokemonParams defines parameters for ListPokemon.
 type ListPokemonParams struct {
 	// Limit Number of items to return
@@ -311,17 +791,32 @@ type ListPokemonParams struct {
 
 	// Rarity Filter by rarity tier
 	Rarity *ListPokemonParamsRarity `form:"rarity,omitempty" json:"rarity,omitempty"`
+
+	// IncludeInactive Also list species a full import no longer found upstream
+	IncludeInactive *bool `form:"include_inactive,omitempty" json:"include_inactive,omitempty"`
 }
 
 // ListPokemonParamsRarity defines parameters for ListPokemon.
 type ListPokemonParamsRarity string
 
+// GetPokemonHistoryParams defines parameters for GetPokemonHistory.
+type GetPokemonHistoryParams struct {
+	// Limit Number of items to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // CreateCatchJSONRequestBody defines body for CreateCatch for application/json ContentType.
 type CreateCatchJSONRequestBody = CreateCatchRequest
 
 // CreateImportJSONRequestBody defines body for CreateImport for application/json ContentType.
 type CreateImportJSONRequestBody = CreateImportRequest
 
+// CreateImportMultipartRequestBody defines body for CreateImport for multipart/form-data ContentType.
+type CreateImportMultipartRequestBody = CreateFileImportRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Create a catch by opening a Pokeball
@@ -330,18 +825,39 @@ type ServerInterface interface {
 	// Get a catch by ID
 	// (GET /catches/{catch_id})
 	GetCatch(w http.ResponseWriter, r *http.Request, catchId openapi_types.UUID)
+	// List imports
+	// (GET /imports)
+	ListImports(w http.ResponseWriter, r *http.Request, params ListImportsParams)
 	// Create an import job
 	// (POST /imports)
-	CreateImport(w http.ResponseWriter, r *http.Request)
+	CreateImport(w http.ResponseWriter, r *http.Request, params CreateImportParams)
+	// Cancel an import
+	// (DELETE /imports/{import_id})
+	CancelImport(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID)
 	// Get import status
 	// (GET /imports/{import_id})
 	GetImport(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID)
+	// Get the diff report of a dry-run import
+	// (GET /imports/{import_id}/diff)
+	GetImportDiff(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID, params GetImportDiffParams)
+	// List species an import skipped
+	// (GET /imports/{import_id}/errors)
+	ListImportErrors(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID, params ListImportErrorsParams)
+	// Stream import progress
+	// (GET /imports/{import_id}/events)
+	StreamImportEvents(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID)
+	// Retry the failed items of an import
+	// (POST /imports/{import_id}/retry)
+	RetryImport(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID)
 	// List imported Pokemon
 	// (GET /pokemon)
 	ListPokemon(w http.ResponseWriter, r *http.Request, params ListPokemonParams)
 	// Get a Pokemon by Pokedex ID
 	// (GET /pokemon/{pokedex_id})
 	GetPokemon(w http.ResponseWriter, r *http.Request, pokedexId int)
+	// List the changes imports made to a Pokemon
+	// (GET /pokemon/{pokedex_id}/history)
+	GetPokemonHistory(w http.ResponseWriter, r *http.Request, pokedexId int, params GetPokemonHistoryParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -360,9 +876,21 @@ func (_ Unimplemented) GetCatch(w http.ResponseWriter, r *http.Request, catchId
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List imports
+// (GET /imports)
+func (_ Unimplemented) ListImports(w http.ResponseWriter, r *http.Request, params ListImportsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Create an import job
 // (POST /imports)
-func (_ Unimplemented) CreateImport(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) CreateImport(w http.ResponseWriter, r *http.Request, params CreateImportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel an import
+// (DELETE /imports/{import_id})
+func (_ Unimplemented) CancelImport(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -372,6 +900,30 @@ func (_ Unimplemented) GetImport(w http.ResponseWriter, r *http.Request, importI
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get the diff report of a dry-run import
+// (GET /imports/{import_id}/diff)
+func (_ Unimplemented) GetImportDiff(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID, params GetImportDiffParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List species an import skipped
+// (GET /imports/{import_id}/errors)
+func (_ Unimplemented) ListImportErrors(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID, params ListImportErrorsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream import progress
+// (GET /imports/{import_id}/events)
+func (_ Unimplemented) StreamImportEvents(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retry the failed items of an import
+// (POST /imports/{import_id}/retry)
+func (_ Unimplemented) RetryImport(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List imported Pokemon
 // (GET /pokemon)
 func (_ Unimplemented) ListPokemon(w http.ResponseWriter, r *http.Request, params ListPokemonParams) {
@@ -384,6 +936,12 @@ func (_ Unimplemented) GetPokemon(w http.ResponseWriter, r *http.Request, pokede
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the changes imports made to a Pokemon
+// (GET /pokemon/{pokedex_id}/history)
+func (_ Unimplemented) GetPokemonHistory(w http.ResponseWriter, r *http.Request, pokedexId int, params GetPokemonHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -432,11 +990,124 @@ func (siw *ServerInterfaceWrapper) GetCatch(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// CreateImport operation middleware
-func (siw *ServerInterfaceWrapper) CreateImport(w http.ResponseWriter, r *http.Request) {
+// ListImports operation middleware
+func (siw *ServerInterfaceWrapper) ListImports(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListImportsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "status", r.URL.Query(), &params.Status, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "source", r.URL.Query(), &params.Source, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateImport(w, r)
+		siw.Handler.ListImports(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateImport operation middleware
+func (siw *ServerInterfaceWrapper) CreateImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateImportParams
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "mode", r.URL.Query(), &params.Mode, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mode", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "join" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "join", r.URL.Query(), &params.Join, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "join", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateImport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelImport operation middleware
+func (siw *ServerInterfaceWrapper) CancelImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "import_id" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "import_id", chi.URLParam(r, "import_id"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "import_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelImport(w, r, importId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -471,6 +1142,152 @@ func (siw *ServerInterfaceWrapper) GetImport(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// GetImportDiff operation middleware
+func (siw *ServerInterfaceWrapper) GetImportDiff(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "import_id" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "import_id", chi.URLParam(r, "import_id"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "import_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetImportDiffParams
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "kind", r.URL.Query(), &params.Kind, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImportDiff(w, r, importId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListImportErrors operation middleware
+func (siw *ServerInterfaceWrapper) ListImportErrors(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "import_id" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "import_id", chi.URLParam(r, "import_id"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "import_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListImportErrorsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListImportErrors(w, r, importId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamImportEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamImportEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "import_id" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "import_id", chi.URLParam(r, "import_id"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "import_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamImportEvents(w, r, importId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryImport operation middleware
+func (siw *ServerInterfaceWrapper) RetryImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "import_id" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "import_id", chi.URLParam(r, "import_id"), &importId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "uuid"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "import_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryImport(w, r, importId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListPokemon operation middleware
 func (siw *ServerInterfaceWrapper) ListPokemon(w http.ResponseWriter, r *http.Request) {
 
@@ -503,6 +1320,14 @@ func (siw *ServerInterfaceWrapper) ListPokemon(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Optional query parameter "include_inactive" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "include_inactive", r.URL.Query(), &params.IncludeInactive, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_inactive", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListPokemon(w, r, params)
 	}))
@@ -539,6 +1364,50 @@ func (siw *ServerInterfaceWrapper) GetPokemon(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
+// GetPokemonHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPokemonHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "pokedex_id" -------------
+	var pokedexId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pokedex_id", chi.URLParam(r, "pokedex_id"), &pokedexId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pokedex_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPokemonHistoryParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPokemonHistory(w, r, pokedexId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -658,447 +1527,141 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/catches/{catch_id}", wrapper.GetCatch)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/imports", wrapper.ListImports)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/imports", wrapper.CreateImport)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/imports/{import_id}", wrapper.CancelImport)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/imports/{import_id}", wrapper.GetImport)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/imports/{import_id}/diff", wrapper.GetImportDiff)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/imports/{import_id}/errors", wrapper.ListImportErrors)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/imports/{import_id}/events", wrapper.StreamImportEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/imports/{import_id}/retry", wrapper.RetryImport)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/pokemon", wrapper.ListPokemon)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/pokemon/{pokedex_id}", wrapper.GetPokemon)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pokemon/{pokedex_id}/history", wrapper.GetPokemonHistory)
+	})
 
 	return r
 }
 
-type CreateCatchRequestObject struct {
-	Body *CreateCatchJSONRequestBody
-}
-
-type CreateCatchResponseObject interface {
-	VisitCreateCatchResponse(w http.ResponseWriter) error
-}
-
-type CreateCatch201ResponseHeaders struct {
-	Location string
-}
-
-type CreateCatch201JSONResponse struct {
-	Body    CatchResponse
-	Headers CreateCatch201ResponseHeaders
-}
-
-func (response CreateCatch201JSONResponse) VisitCreateCatchResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(201)
-
-	return json.NewEncoder(w).Encode(response.Body)
-}
-
-type CreateCatch400ApplicationProblemPlusJSONResponse ProblemDetail
-
-func (response CreateCatch400ApplicationProblemPlusJSONResponse) VisitCreateCatchResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateCatch409ApplicationProblemPlusJSONResponse ProblemDetail
-
-func (response CreateCatch409ApplicationProblemPlusJSONResponse) VisitCreateCatchResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(409)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetCatchRequestObject struct {
-	CatchId openapi_types.UUID `json:"catch_id"`
-}
-
-type GetCatchResponseObject interface {
-	VisitGetCatchResponse(w http.ResponseWriter) error
-}
-
-type GetCatch200JSONResponse CatchResponse
-
-func (response GetCatch200JSONResponse) VisitGetCatchResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetCatch404ApplicationProblemPlusJSONResponse ProblemDetail
-
-func (response GetCatch404ApplicationProblemPlusJSONResponse) VisitGetCatchResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateImportRequestObject struct {
-	Body *CreateImportJSONRequestBody
-}
-
-type CreateImportResponseObject interface {
-	VisitCreateImportResponse(w http.ResponseWriter) error
-}
-
-type CreateImport201ResponseHeaders struct {
-	Location string
-}
-
-type CreateImport201JSONResponse struct {
-	Body    ImportResponse
-	Headers CreateImport201ResponseHeaders
-}
-
-func (response CreateImport201JSONResponse) VisitCreateImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
-	w.WriteHeader(201)
-
-	return json.NewEncoder(w).Encode(response.Body)
-}
-
-type CreateImport400ApplicationProblemPlusJSONResponse ProblemDetail
-
-func (response CreateImport400ApplicationProblemPlusJSONResponse) VisitCreateImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetImportRequestObject struct {
-	ImportId openapi_types.UUID `json:"import_id"`
-}
-
-type GetImportResponseObject interface {
-	VisitGetImportResponse(w http.ResponseWriter) error
-}
-
-type GetImport200JSONResponse ImportResponse
-
-func (response GetImport200JSONResponse) VisitGetImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetImport404ApplicationProblemPlusJSONResponse ProblemDetail
-
-func (response GetImport404ApplicationProblemPlusJSONResponse) VisitGetImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListPokemonRequestObject struct {
-	Params ListPokemonParams
-}
-
-type ListPokemonResponseObject interface {
-	VisitListPokemonResponse(w http.ResponseWriter) error
-}
-
-type ListPokemon200JSONResponse PokemonListResponse
-
-func (response ListPokemon200JSONResponse) VisitListPokemonResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetPokemonRequestObject struct {
-	PokedexId int `json:"pokedex_id"`
-}
-
-type GetPokemonResponseObject interface {
-	VisitGetPokemonResponse(w http.ResponseWriter) error
-}
-
-type GetPokemon200JSONResponse PokemonSummary
-
-func (response GetPokemon200JSONResponse) VisitGetPokemonResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetPokemon404ApplicationProblemPlusJSONResponse ProblemDetail
-
-func (response GetPokemon404ApplicationProblemPlusJSONResponse) VisitGetPokemonResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Create a catch by opening a Pokeball
-	// (POST /catches)
-	CreateCatch(ctx context.Context, request CreateCatchRequestObject) (CreateCatchResponseObject, error)
-	// Get a catch by ID
-	// (GET /catches/{catch_id})
-	GetCatch(ctx context.Context, request GetCatchRequestObject) (GetCatchResponseObject, error)
-	// Create an import job
-	// (POST /imports)
-	CreateImport(ctx context.Context, request CreateImportRequestObject) (CreateImportResponseObject, error)
-	// Get import status
-	// (GET /imports/{import_id})
-	GetImport(ctx context.Context, request GetImportRequestObject) (GetImportResponseObject, error)
-	// List imported Pokemon
-	// (GET /pokemon)
-	ListPokemon(ctx context.Context, request ListPokemonRequestObject) (ListPokemonResponseObject, error)
-	// Get a Pokemon by Pokedex ID
-	// (GET /pokemon/{pokedex_id})
-	GetPokemon(ctx context.Context, request GetPokemonRequestObject) (GetPokemonResponseObject, error)
-}
-
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
-
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
-
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
-
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
-
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
-
-// CreateCatch operation middleware
-func (sh *strictHandler) CreateCatch(w http.ResponseWriter, r *http.Request) {
-	var request CreateCatchRequestObject
-
-	var body CreateCatchJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateCatch(ctx, request.(CreateCatchRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateCatch")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateCatchResponseObject); ok {
-		if err := validResponse.VisitCreateCatchResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// GetCatch operation middleware
-func (sh *strictHandler) GetCatch(w http.ResponseWriter, r *http.Request, catchId openapi_types.UUID) {
-	var request GetCatchRequestObject
-
-	request.CatchId = catchId
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetCatch(ctx, request.(GetCatchRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetCatch")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetCatchResponseObject); ok {
-		if err := validResponse.VisitGetCatchResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// CreateImport operation middleware
-func (sh *strictHandler) CreateImport(w http.ResponseWriter, r *http.Request) {
-	var request CreateImportRequestObject
-
-	var body CreateImportJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateImport(ctx, request.(CreateImportRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateImport")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateImportResponseObject); ok {
-		if err := validResponse.VisitCreateImportResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// GetImport operation middleware
-func (sh *strictHandler) GetImport(w http.ResponseWriter, r *http.Request, importId openapi_types.UUID) {
-	var request GetImportRequestObject
-
-	request.ImportId = importId
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetImport(ctx, request.(GetImportRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetImport")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetImportResponseObject); ok {
-		if err := validResponse.VisitGetImportResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// ListPokemon operation middleware
-func (sh *strictHandler) ListPokemon(w http.ResponseWriter, r *http.Request, params ListPokemonParams) {
-	var request ListPokemonRequestObject
-
-	request.Params = params
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListPokemon(ctx, request.(ListPokemonRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListPokemon")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListPokemonResponseObject); ok {
-		if err := validResponse.VisitListPokemonResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// GetPokemon operation middleware
-func (sh *strictHandler) GetPokemon(w http.ResponseWriter, r *http.Request, pokedexId int) {
-	var request GetPokemonRequestObject
-
-	request.PokedexId = pokedexId
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetPokemon(ctx, request.(GetPokemonRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetPokemon")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetPokemonResponseObject); ok {
-		if err := validResponse.VisitGetPokemonResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xZ247bOBL9FYK7b2tbsmNnNnrLZpCZBoLA6HSwwAaGQUsli2mJVEiqO0bD/77gTdaF",
-	"7b7kNpg3SyJZh1WnDovlO5zyquYMmJI4ucMyLaAi5mdKVFpsBciaMwn6DckyqihnpFwLXoNQFCROclJK",
-	"mOC680pPbvaF2hKlHzKQqaC1nooT/N8CGFIFoDW/hoozdEsksuPxBMNXUtWlXuQTXsSLl9N4OY2XV/NF",
-	"EsdJHP8PbyY456LSK+OMKJgqWgGeYHWoASdYKkHZHh8nmGZj2x8Z/dIAohkwRXMKAvHcYDGbHZhfrWL4",
-	"9zKOp7B4tZsu59lySn6bv5wuly9frlbLZRzHcQ9O09AsiERuZUHZIegLVYBAqqDSYkBUIoLMcHRDBCVs",
-	"4BXj7k1rZcd5CYRpMzW/hh0py639dIeBNZXeiP+AJ3gvgKite2hKJYh/qIhUIOzTpu+IzqRNYHu1jaO2",
-	"+E8BOU7wP6ITqyJHqcgN28qmqog44ONxggV8aaiATFsxvvNrDXfTceKkQ64THL77DKnScFKNFraevl8a",
-	"kOqJ7B15sh+1q0MNmjhrNwwpjngNGvX3cnk7f+zwgdv6WM84hFY1F+qZHpG8EWnIFQWgjCiC7ADtCWsH",
-	"5YJXA4eQmob26V6f36YDENpfu7HnKZXxThaUqitagVSkqtGtFy23O6NZduZYtFbTeD6dr7RovVgmq5c/",
-	"SrQslh+mWgqqbcobFnDM+6baWRx6lHRIIEOSo5yIPqT4FDTKFOxB6OUfy6gdULY/GfhWWk2wVEQ1cmz4",
-	"TSMEMIXs97GPvUVgmV7J8CgFKe2DFrwSLB1yQkvIRpjcxBCmps6eScKSSIXc9O/IxJA2u4i1HuxRZNJN",
-	"pN6GQinrz4KSymcnrmFe78eTTp8WFRGCmOeSVtT4v+PERZi8PM8lDMeGhyquSBmIqn6NWJtHvhiq9LGl",
-	"Ga/j/KUBcehHdR4vVgE7w3gZl3jjfmst7nMh0cGVTwwFUYqk1wN3rFZBf2SQgwt2Z/Ay7LyiHox7EV5U",
-	"1pBSUm7DQOKzc8KA7p8E2WDoq/jhgBQ1nng3nXwwAj5G5U2eDZnj9BPzJ3DUvCd2sqFjBl8dQfsUXIRj",
-	"wEgVkHPPa/N1IIj0mqRFExREQQRVgYL50rxHilpQTpNTXtmisWHtT0GENljCHlhGTBpVB1XQlIyKrXZW",
-	"8LioBVWwbUQgiT9evtMVT/cyY4cjWpH9cL+FUrVMokiQ29meqqLZNRJEypkCpmYpryK9yOv1RWQXkZEt",
-	"ENtHF++I6ztDxPOcaqpMiVC3XFxHi9WstufL6WwXFN9zBD5eMc1gp5fy/gjbz70tf8JQQqoETfFmMzkJ",
-	"9QhQX4pDh4/jj+OFB9MLj99XMFUE35VQbTNQhAYiefn2DXq1XP2G1nYg+t0MlHiYNfct8GdTETYVQDKy",
-	"KwHB17okzGTTgAUCXGHDuEI5b1gWpB1lUhEWKpA+Xl4gATkIYGlbFR78kWH0I6cp4mlqCpoU8BOKoD+v",
-	"rta+Akp5NqDwMl6GzziqygDSD4WuUoq+Z7xY9b3yniv09l5nhK9h5x3hIo7c9bFrjOx4o5JdSdj1wxcP",
-	"u7fWY2NyHU20cm4uE5wpkprCwMohvmwBfgBxQ1NAFaFMEcpAaHYZXWmlwcqC0YKKs2uQKdVzo3abU2lX",
-	"me65PUy7Dnm9vkA5F6481I7wuakL6gnaCX4re9W0+z5BhGX2PqM/m6szyBluIxvYxuv1BZ7gGxDSGo9n",
-	"81lsaqMamK69E/xiNp/NdQYRVRiuRW5le8e2l1CdXCZPLjJdhJsi8o3rxri76n94dvDeBXsdIXVd0tTM",
-	"iz5L23ywwvWQrAXbA8d+1JVowLywhanBu4jn3w9Dv7VmrA8uI6YXJJtU3zDypiwPnetmASTT7Enu8Dtu",
-	"AQRkmajCH05uqusweQXSpG7hdo+MaUu3wMVAY13G8RlfuMz719N8MlDogE8u2A0paYbaoGkgr34BkPe8",
-	"Taw2kw6gjHS0tZijMiLO67uD6RHp/CJt50j7l+yNKPnU2OhVfKJEd5YqNDtq2HsIpMwfoHy+1ESQCpTh",
-	"xqfQxbp5ZP8TJ4/rI2jlw4lJcH9GJ9hDxsOUCvMt2H44bkbpF//09LMMkEiAagSDzDJu+QsYZ/GcKoY+",
-	"0/4A1aXZxe/30srS9UH9vfBNjx8owIN25E9W4GHPMCQ3tsnyvTXY9W7+NiIc1DwvjOgz33XI6OnXI2N0",
-	"54LxgMi1pHy+yg0bpt8kcy3qv6zOPZ7kXul6ZP/1sufQndM9x7O2JRmmWucvqiC93lGp1qe/ns4RbNj6",
-	"Vtw5yrPEN+0cTXzr7eSTDHLSlAoni3iCK/KVVk2Fk3msnyhzT6GO0iOgyGta3wPE9f6CSLqm48eYfktL",
-	"BUKfNqLXkQkZbu/sJ8Pf0Lj5mQkUblMHiOrLQT2wkzc9tmqOjW5eHcZ6jvYYG93VthP3kDo+kr1aHtlD",
-	"TT6cLFZByTthOat5I/r8jBid/la+Nzp/nXqu7Yqerej8qN2hDVWvtuswRs8GcRMO+lrwrEldL6rfcCA1",
-	"nbmoz1Je4XGmf1Bkb//i6s+U9v1stMKmBXjXPyqlWb1DJY2988qXqsfN8f8BAAD//0pP8XuXIgAA",
+	"H4sIAAAAAAAC/+w9a28cN5J/heg7YO9wrdFIlrwXffM5yUaLIGvEzi5wO4LC6a5RM+omOyRb0sCn/36o",
+	"ItlPzkuRbWGTT/bM8FEs1vtBfUwyVdVKgrQmufiYmKyAitN/M26z4lqDqZU0gN/wPBdWKMnLd1rVoK0A",
+	"k1yseGkgTereVzi5uSnsNbf4IQeTaVHj1OQi+UcBktkC2Dt1C5WS7J4b5sYnaQIPvKpLXOSfyen89PXR",
+	"/Oxofvbh5PRiPr+Yz/83uUqTldIVrpzk3MKRFRUkaWLXNSQXibFayJvkMU1EPt37Jyl+bYCJHKQVKwGa",
+	"qRXBQocdbX9+Pof/PpvPj+D0q+XR2Ul+dsT/fPL66Ozs9evz87Oz+Xw+H4DTNCKPQmKuTSHkOooLW4Bm",
+	"thDGwcCEYZzRcHbHteByhBVC91W7y1KpErjEbWp1C0teltfup48JyKbCg4QfkjS50cDttf/QlFbz8KHi",
+	"xoJ2n66GiOhNuoocr3b3iDv+u4ZVcpH823FHVceepI79sGvTVBXX6+TxMU00/NoIDTnuQrgLa41P00Ni",
+	"2iOuDhy1/AUyi+BkCC1cB/L9tQFjD6TeCSaHt/ZhXQMSzjs/jFnFVA0I9XOhvJ0/RfgIbUNYtyBkJUq4",
+	"FlWttO2jZXhyHDQ98A9f//X9335gSrO37//OtLo3M/ahAOYon61UWap7Q4xUc23/ZFimpAVpGQLDuMwX",
+	"csXL0rAlz24RXTgU92LwYEEaoST7j5nMfzFKpmyG/5S43Swzd/85WyBmWy5bConkswsvdJIt6Jhi4gAC",
+	"WWlVTdH0rdDGElXk8MAuvw7CRXN5A3hqt2fKcljxprQGvzsZsvfJ+elVmlRCigop6aQ9gJAWbkDjCSqV",
+	"+0uiZZKLZNUQQQ3B+U7dsxXYrICcmRoyAYZxDexeC2tBztgbhvM8VEwD/gCGwR3o9UL6KSnjkgmZaahA",
+	"Wt4ON7eiNu2694Uy0N56LnImlV3IrKCjGyEzQFSs2T1oYCU3tg9GrtdMN5J5AKSyhZA3jm40ZErnhhXq",
+	"3lHN6ES5WK1AM7yRIMl5qW6YkMYCz9OFNADsL998YMcOcnP80d+9yB+Pcfps0Wddj8vekRGzen2tGzlm",
+	"0/6gTaIxh4drkZspuXSEYjrimLG3XEpl2RLRWS2FhJzdC1u4EyrNrJqxfwhbqMYuZG+DtDfC3WGLI2H8",
+	"6pD7s3Zn+OdJepb++eoqTYSFisDcQX384dKNPJnP50Sr4XM7nGvN1zjYqEZnMRFaAJO8IjHKWc4tZ24o",
+	"0tBK3DQactbIHDT72V/bzA0wP88W8qe6VDw3rDFEVmzZiNIeCcl+Rrb/OazlaWByZroXXovonVk1Bfd7",
+	"fjhj28IT+q1U9zLcxRCQ0/OTHdw+kmoenzG5thJQ5teO4w4VZzg1Ivb9BRFb0bo5o6Ep44YJyYJKz8Fy",
+	"UY5wrLkWdh1FsYT76W5/52XjbtNLGM/p02UhuXpME1XmmxYRsi8KRgs0MlNVpWRyNVUZiAa3sgMyhmcv",
+	"PFBwPNU6bjmt/c82w8lTDu2YPLYQtTxWikqQAusT1vwqxrtqtTIwHhsfGsy0HcD1sRGmEBOhSJyyPX7N",
+	"ZFMtnekdBFSFxhqKfLy2WyGRzkoLeqQbX1/tZhAPQxBnAZSAphYHu662d/5D/B7HJhFempzZCXUkOdOn",
+	"+nvVlDlTd6BJG44wcBa9qyg/TXfsq8dcOUXLCn4HbA0jL+M0uo+GSt1tP11Yv7+nl8ZSsVLJG9AkEQ3j",
+	"S9WMtp3vvmA8awdJ2mJ8y32C1kofepFkJebbPVhamPxXZ6VMhNXp/PT8aH5ydHKOHuyrs4vz1wd4sLT8",
+	"dVZyY2JArB1yPaIzohtvOQRt3zNrCmvra2O5bYgpRAUO+xWva9wvTazm0uC8sZXTnxoT5/3fpy53bawG",
+	"XrEgK5kbmTIDlt0jKnvnRFNlCGkPkPP5qyhZVmAMv4lYGd/Q/fifmQaHFHRb+qib6Ad4qCHDgQ4Idj5/",
+	"xd6DvhMZsJ8kv+Oi5MuS3J+gAtnp+Q4DMKZeHS22hkUnFwm6W1HXkMehPD3fzSm9vYe01GEs7dP5Lg66",
+	"LoWxn0fl9bf9fCrvQKXVE9r+roZ39Gr3FT1dR8EdyIhoei95bQplya6WHrw/GVZrdaPBGGZAWtIuBD6t",
+	"Ypjj0GR8Z4EPrjPVSLtN6kdQYrm+AUtmIp0udbxOxnASw/6Ki/KQvVr+UGzFdXxJZ0Huv2brXG5es/Ud",
+	"D4guujnJHqHCGrQR5iCcez86uBytAt58hE2i+m2jNZKH+30CfBvbApk7jVFrlYEx7gNuoHVTO7WDTF2C",
+	"+7+7WfySywzKcqCtN4RvOjS38I7vc4qtERFtYR8c1+jdQmyqc7nMkZI1MM7cboHil1rdor+0mjDSDgXV",
+	"93ncimgbjtTSCryJ7DXOBRt/w05fXwRbq+nUVFwv7U9nJKP6MBY8b4mOeFoEuPfQUWlSF9xEUPEOv46g",
+	"QshZIHUHK90AnZ3VSuBn27MyU9bUBnT7LYYWltxAStM0ZIoiIxwNbX0LGk/GMWKFWC9ECRiIksEVCbJM",
+	"KaZWGKzqscEAqECcSZq4/clGdZuNzSk3cHdgl/DU19XjS9tC359dVbd7vTRt7eAbuZi/NkA+Ym+Ts9NP",
+	"qa6fmkbb4oV8EBUYy6vaMWHfhcSEmpv5vP6IsaIieAxkSubmWkPFBXJLRLSFwcwPZo20omScdVqjZXUh",
+	"hSnApJgO0LxWJU2keKawhtU8GznCX8Vp4pktFgOWKZmhqHfSgmkwqrxDsWRNmDBy0ecb5N6+9k2Qt9xu",
+	"9ul28UZPve3Bu2E0TnQ3cSjFtdo+DWLb+8Wdxt9GiGfzi5PTAwjxSemXcWBxfG9RRD7NzPs0WWRpQO+n",
+	"sT2l9EmJ8i4S7kdmYkoXRWS2dA4tD9mYfegMN9iXoluoWst05/JdouuAvNYTUzk0NmoqcQ3SXm+x+z/0",
+	"ZEjR5lqYBqun4YVno4cadIaABd6LOIMFosUTZxfU8CgruMzL9jp89mhOKcn5fKwbZ+cD5lQNGpYtUE7b",
+	"HpbwmrAjyt2gGhEKlLDEujvzVRHb4IA0VD/5tIROK3kFdEDmyFiu99TWrUJZkdCqRXaLCa96sxz5bar7",
+	"KS7fjL2RrOfV9c0LYxU538v1QmK5SmMtYi7HTJeGuhQZJ4NbGFSZTdXX5lkB2S2Z7sPE6zP5lYPb8ks+",
+	"T55vQrIpa6QBS3LTZVx35vqmtNr4LPlvEewhFrKEjPuc6Dpk4X3qrp9np40cD4IkIUU+nGokJl09OLN9",
+	"JHRT5080UCk16qc/K6kHkJ6OzeDcUpiMSwYPwhB5I7LWuxHp9KlH+8EJDhfycAKrF/sgXTjQuBOTYHz2",
+	"KW2NrNBB+HdwmTFXJmR7n5RiDnAcSChaWejln5/VinRLmpghOU7KuXScspCk+3nFg2R8RCFtsSYugyXB",
+	"bZt175VLfhpzYkv0rXdzHc620UchjFV6/VSf97Dww4goX1r0weMrWN3RO/w0eYKAmM8XCZoUeL6wu/DI",
+	"3xkJ2uDBP9+VoEw3B14Ft5ZntyN0nMcjDTmswF9234iPI6+oR+NexRclm4aX13FA5lvnxAHaPAny0dCv",
+	"9tCbRZ2kAU0dDiaAT6EKW269sidVg+RApup+DkG/FrMrlXBGWT/R3/iE+oxdSp5ZcQcDLxgtvpKixOja",
+	"u1JptlyH0rSULRsy99dsmMhujRwqnAYze1Zde0D+e68kguQVxB1M5HD6deQJiFueFU3UE/CVapPlfqTv",
+	"mRWge26Krx5Lu0IyWgE3LOEGZO7Kj6q1LUTGJ5Xd7ayoB1lrYeG60RFx9tOP34/UCHPDmaj4xDQqrK3N",
+	"xfGx5vezG2GLZtkY0L5Ad5ap6hgXefPu8tgtYo5dNXr70VP+sbIF6GO1WglkmiOuLXqux6fns1reDAig",
+	"0WKT67m/7qDBXnOYzTfsfh4GBhIoIbNaZEksOtABNFRKMevb04+niwDM4HrCuaJCQ6tlCVUoi5zS1bdv",
+	"2Vdn539m79xA9jUNNMlUfsQX+K6puDzSwHNiX3ioSy6Jm0ZUoKGtvfL+XZTshDQWXegY0V0yDSvQILM2",
+	"5LkOypPkzkpkTGUZBRKyg4IP33348C5EHjLn3gz0VbzYzQpbRoNdlJscYqarBuxj5Qdl2bcbkRHv+diO",
+	"CH/jRJajzajK7WJZcnm7O+fnztZiLEZcg2rQp/hgezk8oypElwpBNRE8ki7E8Sz+ENZ8xiO9fdXnav3N",
+	"pNi/J523lAf2b2VaNdgRQFy39Iuhu9aIrmSkkSUY5/5TPWAAYF8ltK1SbBqRep6iMC/nCPlTWnskybCi",
+	"MBkqDp6RGePQk/zYMkMojcNUoOVCgka4SIe1asipINI7lZK3YDKBc49bljoybpWjG+VM2P7537y7JOJz",
+	"xIlMF/QABm9TrLy4N4PIrf/dZf0pyDEybbwUiRzjzbvLJE3uQBu3+Xx2MpsjTKoGyWuRXCSvZiezkyRN",
+	"am4LIv1jv3JCzWOunQg5j2TyZY6BVoQB3vo2Q9919D8qXwfs+nIuXlPwFOcdYxtU15S5i8WifW+Pw/u3",
+	"ugH6wrmDBO/p/OT5YBj2jD4+Tq6SMMBMk2VgDJq8616qugCegyaovlcOgAgzcFu0WSw31V0sC9ouSXvg",
+	"9s2To5bcIhEQhPVsPt+CCy/l/+swnIysgQhOLuUdL0XO2ktDQL76AoD8oFrGajlpDZaESOsBeVJm3GN9",
+	"uabmR2rYalsiEb/8xolazxrUbhEY5fijIxWRPyLYNxBhmb+ADfxSc80rsEQb/4wlcZo9G3uTi/0iZij5",
+	"kgti8CAnL5IAcjJmqTi9xQNtVxP2m3929nMUgFrKNlpC7iju7AtQnIOns06HlPYXsH0yu/x6I1n5Kp8e",
+	"LY1MbjqpaauBjCNuCfdgrEvCoVYYEuD3wthLv/AOGpyUbyiP3EBJIc7kSSlEizo8ts2cp3PqsfMdYb7D",
+	"bkt/2B6gYHpoAyA+XBWFpL/1fJ+tv6WmHbyqUBcdshexrdsfu60/dYnpZoB7WeBN4E51y871/ybLtSeE",
+	"lvSCzuKWKc34yob+fx87ie3dpmlWrikqIm62hF8Og2sJK6Vhb5Dc8MNh+pRyMFoEGVO8jkpx3EgWvggz",
+	"YCALURiFu+qJwfDN1WPaWp4j45lR8/5S5cHUMl1rgO8Zll2fZ9d+6/tt23Zb1PDTlwBSBoLesODOgfTQ",
+	"u/1U+Hohqdu/5tq1JrCqKa2gj0gtaQeZexbAQ6dWDB54Zsu1W6QhQDr3c7aQC0nErGQ7qQbdNhNziXVN",
+	"Xpyk/dJDpfu1DQvJUdUgraaMZ1oZw/BlB1/LYGbsH1QcTNtQqcqqFDeFRQeDImR+84UMDhq6ix4KKoL4",
+	"xVXfUNff2fwrckw4C2Yuc6avq2gW8mYhvYUr5JHbqe0x9o7mL0pIXNmAJSx8Q/UHWt2zjGsdSht9f6Fa",
+	"eduMCsc7HzClUGnqwmnYKt8GuFJW1ClzMfOU+VB5yobB9O5zGIBLAOQpw5rra3ioQZOjl7KM17bRcK25",
+	"hZQJc90GS+lTCJe6pn8umap9dFj7KCzmQ4VhOWiBBZiluHWBax/HbOWYL0lfSGFYCSvLoKrtekYE2/i+",
+	"cQkogAPSEWl4HQZQx1tw6KCrWshF8n+LxD134WYjEMSoJC1x7nLdLtHeM0dSvi9UCQQQVsngGKIdmu1q",
+	"X2JO4mUo/9lqcbhRrFI5+GYfB97MsbtnQ6IPogQa6BujiTdDc/wG+e7rDSJmQSir27fKbqc2csZZlNxZ",
+	"hJ2IhU7n83ACHOPwHsJx/vDu1iZL3gLUhiqRsFaH8IJX56t5N+ED+S2ODx9rGz/I4zTcJ/PzR++XoLLo",
+	"r/lw5F5TGa67zyMqrWQ+xtFHaBgdDFzsrRlc28KDPc7M3aFQ7RHEeHbrYZvh8FdF73LsSbFPiWxMlv3t",
+	"wY3nDPTsgSMvoJ471PNs6Pidx3reDOwWFjdbWjvnhZDw2cmrL4AqZ/5SF1iJSmJDPKy1qX9Ry6iF3gtU",
+	"9N8g6ioFImFjcmf3swi2xsHGNcW/KRDWLw97mZGw/eVTFzD4YkGwy1D00UbBvpRQ8JDwUgPP16EvaxyW",
+	"c0TZEfwGd3RTUPcPav501BwiuwOt++XDvFMKn8R5xxHDvcXncciERwO/GDYxgyQyNTgdYfW239KXWKlN",
+	"T8mlC6l0DpraHXrdATP2A9xPaq+Gb09h+iQNSfP2TT0XcXIdA+Shb0m9z9iPLpccdlpI3Gq0zbLpF425",
+	"LgSvhkNf9H0hsoIpDJYQChYScYD7jF6Lw6CJhbJ07pN7NYXdYKzHd0h3wNIaJubKtnz+tVit/tV4PR5P",
+	"pUsdvO5EMVR6zkqtPHV54ybmYeLAaDR895NHmyH7XSYmPoMsHj7/FhF5SPeBeV5ObJmQzHrM+GJUAlOa",
+	"PnT9p1MdQQ869PCqVhNpfpDmAK2VNhsT0F3+7xs38F9djP0hLD6NsIg9n7WZJRxVvnyr7fu+vuu83+4t",
+	"rAMY8S48QB814t5TwbsJpZbuJav+C1cYbsf6LdBH70Fa9g2tN2MuKUKrL2TIi7Sz3CNaOJdC5hjpxOY+",
+	"X5HqfhT+yazQmbaQ/mevhFOfyGnBgrt2ONlaLqlGbYKhsZJ9cAkx7YxAaZk77jK80dhrygUa7V/oQmiy",
+	"UhnI3bMUBI/HAP4Ue4Wh9W8pSzR4QQnnlNg/QICObTuUwYYpZ2f6PBg+pqzv2vJf34fwN1uAvhcGmLAM",
+	"fm14adjorZrZQg7f5sC9VSWshdw/DNLfOfLKRszIdHThRbQjod+dV0khdaKiI3cfvdaZ5CKhXy48TS8k",
+	"0vgF+7jowFskF4u9jrlI0oWv2aA5XS6VfhlQ1gJF7WL8YJH/tt+C6r8a0sYCNcbp+SMSrIc/sNfnPMHJ",
+	"afwMJ/MDT7G1ZmQidR1Rdw3K/pG+F6sGPLwe2HBRB4l/Ddb1VsUrGN62tQFZIcq8e2uDd49HtLK27RAp",
+	"gN2IO5Cj98SUJEsTXV6mgeONTyu/fkSAni1O5e0ovX7houWzpocIxa3N8HKTRH8EhPF+rCjL8DJeygre",
+	"/ZGGQNtU6/PlckppMLmALUuV3YZiJMd1T7r9gZRz5Iprejni3JK+EbpR5PX+QM5GP7PrDP+jzvSAOlM9",
+	"aNGMbdw28U3jak/o5NzpQr8pjRpGArd39oZu3g3QC5mVTQ7Xwrf6PqEC5RO5tvH3BSIiJCjkUZXlpuLG",
+	"rnmnx09+syE/HX/sCtm2dhHsyVvub5Ls6ElOLk7Po0p50Fi1WSt/1vDD9E9ubbydl9MSECDa3hQQRg0y",
+	"MgdRzLF/p2RH6sjlRsT2R1lCuL8tuMT6w/bRmHTYZ8B8T4Fbjef5aC2lXd2iwNDEQvqOwmFv/4aUi1/i",
+	"O3+yF0nwf0QdPxfbTx7i2cJtfuwLSlX0m171C0pc7JJPpMu6J6tM7wXinP5YFN+u4HAxCmXGWPadVnmT",
+	"0Ydxiy2vxczzLPbZJlPqfm/5jWukGc407vvZZIWrFsCPQ9/T0Oo9QYCw974KzVmPV4//PwD0YqXWYnQA",
+	"AA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,414 @@
+package referencehttp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reference-service-go/internal/core/pokemon"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxUploadBytes = 32 << 20
+
+	multipartMediaType = "multipart/form-data"
+	ndjsonMediaType    = "application/x-ndjson"
+	ndjsonAltMediaType = "application/ndjson"
+	csvMediaType       = "text/csv"
+
+	uploadFilePart = "file"
+	csvTypesSep    = "|"
+)
+
+var (
+	errUploadFileRequired    = errors.New("file is required")
+	errUnsupportedFormat     = errors.New("unsupported upload format")
+	errEmptyUpload           = errors.New("upload contains no rows")
+	errDuplicatePokedexID    = errors.New("duplicate pokedex_id")
+	errMissingCSVColumn      = errors.New("missing csv column")
+	errUnknownCSVColumn      = errors.New("unknown csv column")
+	errCSVHeaderRequired     = errors.New("csv header row is required")
+	errInvalidUploadedNumber = errors.New("invalid number")
+	errInvalidUploadedBool   = errors.New("invalid boolean")
+)
+
+// uploadFormat is the encoding of uploaded rows.
+type uploadFormat string
+
+const (
+	uploadFormatNDJSON uploadFormat = "ndjson"
+	uploadFormatCSV    uploadFormat = "csv"
+)
+
+// requiredCSVColumns must be present in the header of a CSV upload.
+var requiredCSVColumns = []string{"pokedex_id", "name", "types"}
+
+// uploadRow is a single uploaded Pokemon in the shape of the catalog.
+type uploadRow struct {
+	PokedexID      int      `json:"pokedex_id"`
+	Name           string   `json:"name"`
+	Rarity         string   `json:"rarity"`
+	Types          []string `json:"types"`
+	SpriteURL      string   `json:"sprite_url"`
+	HP             int      `json:"hp"`
+	Attack         int      `json:"attack"`
+	Defense        int      `json:"defense"`
+	SpecialAttack  int      `json:"special_attack"`
+	SpecialDefense int      `json:"special_defense"`
+	Speed          int      `json:"speed"`
+	BaseExperience int      `json:"base_experience"`
+	CaptureRate    int      `json:"capture_rate"`
+	IsLegendary    bool     `json:"is_legendary"`
+	IsMythical     bool     `json:"is_mythical"`
+}
+
+// uploadLineError reports the line of an upload that could not be imported.
+type uploadLineError struct {
+	line int
+	err  error
+}
+
+// Error returns the message of the underlying error prefixed with the line.
+func (e *uploadLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.line, e.err)
+}
+
+// Unwrap returns the underlying error.
+func (e *uploadLineError) Unwrap() error {
+	return e.err
+}
+
+// uploadImportParams reads the options and rows of a file import upload.
+func uploadImportParams(
+	w http.ResponseWriter,
+	r *http.Request,
+	mediaType string,
+	params CreateImportParams,
+) (pokemon.CreateImportParams, error) {
+	importParams := pokemon.CreateImportParams{Source: pokemon.SourceFile, Mode: pokemon.ImportModeFull}
+
+	if params.Mode != nil {
+		if !params.Mode.Valid() {
+			return pokemon.CreateImportParams{}, fmt.Errorf("%w %q", errUnsupportedMode, *params.Mode)
+		}
+
+		importParams.Mode = pokemon.ImportMode(*params.Mode)
+	}
+
+	upload, err := readUpload(w, r, mediaType)
+	if err != nil {
+		return pokemon.CreateImportParams{}, err
+	}
+
+	importParams.Upload = upload
+
+	return importParams, nil
+}
+
+// readUpload reads the Pokemon uploaded as the request body or as the file
+// part of a multipart form.
+func readUpload(w http.ResponseWriter, r *http.Request, mediaType string) ([]pokemon.Pokemon, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+
+	if mediaType != multipartMediaType {
+		format, err := partFormat(mediaType, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return parseUpload(r.Body, format)
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("reading multipart form: %w", err)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errUploadFileRequired
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("reading multipart form: %w", err)
+		}
+
+		if part.FormName() != uploadFilePart {
+			continue
+		}
+
+		format, err := partFormat(part.Header.Get("Content-Type"), part.FileName())
+		if err != nil {
+			return nil, err
+		}
+
+		return parseUpload(part, format)
+	}
+}
+
+// partFormat tells the format of an uploaded file by its content type and
+// falls back to its extension.
+func partFormat(contentType, fileName string) (uploadFormat, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == ndjsonMediaType, mediaType == ndjsonAltMediaType:
+		return uploadFormatNDJSON, nil
+	case mediaType == csvMediaType:
+		return uploadFormatCSV, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ndjson", ".jsonl":
+		return uploadFormatNDJSON, nil
+	case ".csv":
+		return uploadFormatCSV, nil
+	}
+
+	return "", fmt.Errorf("%w %q", errUnsupportedFormat, contentType)
+}
+
+// parseUpload decodes and validates uploaded rows. The upload is rejected as
+// a whole on the first invalid row.
+func parseUpload(body io.Reader, format uploadFormat) ([]pokemon.Pokemon, error) {
+	var (
+		rows  []pokemon.Pokemon
+		lines []int
+		err   error
+	)
+
+	switch format {
+	case uploadFormatNDJSON:
+		rows, lines, err = parseNDJSON(body)
+	case uploadFormatCSV:
+		rows, lines, err = parseCSV(body)
+	default:
+		return nil, fmt.Errorf("%w %q", errUnsupportedFormat, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errEmptyUpload
+	}
+
+	seen := make(map[int]struct{}, len(rows))
+
+	for i, p := range rows {
+		err = p.Validate()
+		if err != nil {
+			return nil, &uploadLineError{line: lines[i], err: err}
+		}
+
+		if _, ok := seen[p.PokedexID]; ok {
+			return nil, &uploadLineError{line: lines[i], err: fmt.Errorf("%w %d", errDuplicatePokedexID, p.PokedexID)}
+		}
+
+		seen[p.PokedexID] = struct{}{}
+	}
+
+	return rows, nil
+}
+
+// parseNDJSON decodes one row per non-blank line and returns each row's line.
+func parseNDJSON(body io.Reader) ([]pokemon.Pokemon, []int, error) {
+	var (
+		rows  []pokemon.Pokemon
+		lines []int
+	)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxUploadBytes)
+
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+
+		var row uploadRow
+
+		err := decoder.Decode(&row)
+		if err != nil {
+			return nil, nil, &uploadLineError{line: line, err: err}
+		}
+
+		rows = append(rows, row.toPokemon())
+		lines = append(lines, line)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading upload: %w", err)
+	}
+
+	return rows, lines, nil
+}
+
+// parseCSV decodes rows below a header row naming their columns and returns
+// each row's line.
+func parseCSV(body io.Reader) ([]pokemon.Pokemon, []int, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errCSVHeaderRequired
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading csv header: %w", err)
+	}
+
+	for _, column := range requiredCSVColumns {
+		if !slices.Contains(header, column) {
+			return nil, nil, fmt.Errorf("%w %q", errMissingCSVColumn, column)
+		}
+	}
+
+	// Empty values are valid for every known column.
+	for _, column := range header {
+		err = (&uploadRow{}).setCSVField(column, "")
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var (
+		rows  []pokemon.Pokemon
+		lines []int
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, lines, nil
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		var row uploadRow
+
+		for i, column := range header {
+			err = row.setCSVField(column, record[i])
+			if err != nil {
+				return nil, nil, &uploadLineError{line: line, err: err}
+			}
+		}
+
+		rows = append(rows, row.toPokemon())
+		lines = append(lines, line)
+	}
+}
+
+//nolint:cyclop // One case per column.
+func (row *uploadRow) setCSVField(column, value string) error {
+	var err error
+
+	switch column {
+	case "pokedex_id":
+		row.PokedexID, err = parseCSVInt(column, value)
+	case "name":
+		row.Name = value
+	case "rarity":
+		row.Rarity = value
+	case "types":
+		row.Types = strings.Split(value, csvTypesSep)
+	case "sprite_url":
+		row.SpriteURL = value
+	case "hp":
+		row.HP, err = parseCSVInt(column, value)
+	case "attack":
+		row.Attack, err = parseCSVInt(column, value)
+	case "defense":
+		row.Defense, err = parseCSVInt(column, value)
+	case "special_attack":
+		row.SpecialAttack, err = parseCSVInt(column, value)
+	case "special_defense":
+		row.SpecialDefense, err = parseCSVInt(column, value)
+	case "speed":
+		row.Speed, err = parseCSVInt(column, value)
+	case "base_experience":
+		row.BaseExperience, err = parseCSVInt(column, value)
+	case "capture_rate":
+		row.CaptureRate, err = parseCSVInt(column, value)
+	case "is_legendary":
+		row.IsLegendary, err = parseCSVBool(column, value)
+	case "is_mythical":
+		row.IsMythical, err = parseCSVBool(column, value)
+	default:
+		return fmt.Errorf("%w %q", errUnknownCSVColumn, column)
+	}
+
+	return err
+}
+
+func (row *uploadRow) toPokemon() pokemon.Pokemon {
+	rarity := pokemon.Rarity(row.Rarity)
+	if rarity == "" {
+		rarity = pokemon.AssignRarity(row.IsMythical, row.IsLegendary, row.BaseExperience)
+	}
+
+	return pokemon.Pokemon{
+		PokedexID:      row.PokedexID,
+		Name:           row.Name,
+		Rarity:         rarity,
+		Types:          row.Types,
+		SpriteURL:      row.SpriteURL,
+		HP:             row.HP,
+		Attack:         row.Attack,
+		Defense:        row.Defense,
+		SpecialAttack:  row.SpecialAttack,
+		SpecialDefense: row.SpecialDefense,
+		Speed:          row.Speed,
+		BaseExperience: row.BaseExperience,
+		CaptureRate:    row.CaptureRate,
+		IsLegendary:    row.IsLegendary,
+		IsMythical:     row.IsMythical,
+	}
+}
+
+func parseCSVInt(column, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q in column %s", errInvalidUploadedNumber, value, column)
+	}
+
+	return n, nil
+}
+
+func parseCSVBool(column, value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w %q in column %s", errInvalidUploadedBool, value, column)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,188 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for requests rejected by an open circuit breaker.
+var ErrCircuitOpen = errors.New("pokeapi circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState string
+
+// Circuit breaker states.
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects every request until the cooldown has passed.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single probe request through to decide whether to
+	// close or open the breaker again.
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerConfig configures a circuit breaker.
+//
+// A zero FailureThreshold disables the breaker.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed requests that trips
+	// the breaker.
+	FailureThreshold int
+	// Cooldown is how long the breaker stays open before it lets a probe
+	// request through.
+	Cooldown time.Duration
+}
+
+// BreakerStatus is a snapshot of a circuit breaker.
+type BreakerStatus struct {
+	State               BreakerState
+	ConsecutiveFailures int
+	// OpenUntil is when an open breaker lets a probe request through.
+	OpenUntil time.Time
+}
+
+// Breaker is a circuit breaker for the requests to a PokeAPI source.
+//
+// Requests that fail with a transport error, a timeout, status 429 or a 5xx
+// status count as failures; any other response closes the breaker again.
+// Requests cancelled by the caller do not count.
+type Breaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewBreaker creates a closed circuit breaker.
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	return &Breaker{cfg: cfg, now: time.Now, state: BreakerClosed}
+}
+
+// Transport wraps next so requests are rejected with ErrCircuitOpen while the
+// breaker is open.
+func (b *Breaker) Transport(next http.RoundTripper) http.RoundTripper {
+	if b.cfg.FailureThreshold <= 0 {
+		return next
+	}
+
+	return &breakerTransport{breaker: b, next: next}
+}
+
+// Status returns the current state of the breaker.
+func (b *Breaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{State: b.state, ConsecutiveFailures: b.failures}
+	if b.state == BreakerOpen {
+		status.OpenUntil = b.openUntil
+	}
+
+	return status
+}
+
+// allow reports whether a request may be sent, moving an open breaker whose
+// cooldown has passed to half-open.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && !b.now().Before(b.openUntil) {
+		b.state = BreakerHalfOpen
+	}
+
+	switch b.state {
+	case BreakerOpen:
+		return b.openError()
+	case BreakerHalfOpen:
+		if b.probing {
+			return b.openError()
+		}
+
+		b.probing = true
+
+		return nil
+	case BreakerClosed:
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of a request.
+func (b *Breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if !failed {
+		b.state = BreakerClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == BreakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = BreakerOpen
+		b.openUntil = b.now().Add(b.cfg.Cooldown)
+	}
+}
+
+// release gives up the probe of a half-open breaker without an outcome.
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) openError() error {
+	return fmt.Errorf("%w after %d consecutive failures, retrying after %s",
+		ErrCircuitOpen, b.failures, b.openUntil.Format(time.RFC3339))
+}
+
+// breakerTransport rejects requests while its breaker is open.
+type breakerTransport struct {
+	breaker *Breaker
+	next    http.RoundTripper
+}
+
+// RoundTrip sends the request if the breaker allows it and records the outcome.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := t.breaker.allow()
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if errors.Is(req.Context().Err(), context.Canceled) {
+		t.breaker.release()
+
+		return resp, err //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+	}
+
+	t.breaker.record(err != nil || failedStatus(resp.StatusCode))
+
+	return resp, err //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+}
+
+// failedStatus reports whether a response status means the source is failing.
+func failedStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
@@ -0,0 +1,94 @@
+package pokeapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reference-service-go/internal/outgoing/pokeapi"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("opens after consecutive failures and rejects requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a breaker tripping after two failures in front of a failing server
+		server, hits := newFlakyServer(t, 10, http.StatusServiceUnavailable, "")
+		breaker := pokeapi.NewBreaker(pokeapi.BreakerConfig{FailureThreshold: 2, Cooldown: time.Minute})
+		client := &http.Client{Transport: breaker.Transport(http.DefaultTransport)}
+
+		// when: sending three requests
+		for range 2 {
+			resp := doRequest(t.Context(), t, client.Transport, server.URL)
+			testastic.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+		testastic.NoError(t, err)
+
+		_, err = client.Do(req) //nolint:bodyclose // The breaker rejects the request without a response.
+
+		// then: the third request is rejected without reaching the server
+		testastic.True(t, errors.Is(err, pokeapi.ErrCircuitOpen))
+		testastic.Equal(t, int32(2), hits.Load())
+		testastic.Equal(t, pokeapi.BreakerOpen, breaker.Status().State)
+		testastic.Equal(t, 2, breaker.Status().ConsecutiveFailures)
+	})
+
+	t.Run("closes after a successful probe", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an open breaker whose server has recovered
+		server, hits := newFlakyServer(t, 1, http.StatusInternalServerError, "")
+		breaker := pokeapi.NewBreaker(pokeapi.BreakerConfig{FailureThreshold: 1, Cooldown: 50 * time.Millisecond})
+		transport := breaker.Transport(http.DefaultTransport)
+
+		doRequest(t.Context(), t, transport, server.URL)
+		testastic.Equal(t, pokeapi.BreakerOpen, breaker.Status().State)
+
+		// when: sending a request after the cooldown
+		time.Sleep(100 * time.Millisecond)
+
+		resp := doRequest(t.Context(), t, transport, server.URL)
+
+		// then: the probe succeeds and closes the breaker
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, int32(2), hits.Load())
+		testastic.Equal(t, pokeapi.BreakerStatus{State: pokeapi.BreakerClosed}, breaker.Status())
+	})
+
+	t.Run("does not count client errors or cancelled requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a breaker tripping after one failure
+		notFound, _ := newFlakyServer(t, 10, http.StatusNotFound, "")
+		hanging := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		t.Cleanup(hanging.Close)
+
+		breaker := pokeapi.NewBreaker(pokeapi.BreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+		client := &http.Client{Transport: breaker.Transport(http.DefaultTransport)}
+
+		// when: a request is answered with not found and another one is cancelled
+		doRequest(t.Context(), t, client.Transport, notFound.URL)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, hanging.URL, nil)
+		testastic.NoError(t, err)
+
+		_, err = client.Do(req) //nolint:bodyclose // The request is cancelled before a response arrives.
+
+		// then: the breaker stays closed
+		testastic.True(t, errors.Is(err, context.Canceled))
+		testastic.Equal(t, pokeapi.BreakerClosed, breaker.Status().State)
+	})
+}
@@ -0,0 +1,324 @@
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrCacheMiss is returned by a ResponseCache that holds no response for a URL.
+var ErrCacheMiss = errors.New("pokeapi response not cached")
+
+const (
+	// cacheEventName names the span event recorded for every cached request.
+	cacheEventName = "pokeapi.cache"
+	// cacheEvictionInterval is how often expired responses are evicted at most.
+	cacheEvictionInterval = time.Hour
+)
+
+// Cache results recorded on the span event.
+const (
+	cacheResultHit         = "hit"
+	cacheResultRevalidated = "revalidated"
+	cacheResultMiss        = "miss"
+)
+
+// CachedResponse is a raw PokeAPI response kept by a ResponseCache.
+type CachedResponse struct {
+	URL          string
+	ETag         string
+	LastModified string
+	ContentType  string
+	Body         []byte
+	// ValidatedAt is when the response was last fetched or revalidated.
+	ValidatedAt time.Time
+}
+
+// ResponseCache stores raw PokeAPI responses by URL.
+type ResponseCache interface {
+	// GetResponse returns the cached response for the URL, or ErrCacheMiss.
+	GetResponse(ctx context.Context, url string) (CachedResponse, error)
+	// PutResponse stores a response, replacing the one cached for its URL.
+	PutResponse(ctx context.Context, resp CachedResponse) error
+	// MarkResponseValidated records that the cached response for the URL was
+	// revalidated at the given time.
+	MarkResponseValidated(ctx context.Context, url string, at time.Time) error
+	// DeleteResponsesValidatedBefore evicts the responses last fetched or
+	// revalidated before the given time and returns how many it evicted.
+	DeleteResponsesValidatedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// CacheConfig configures NewCachingTransport.
+type CacheConfig struct {
+	// TTL is how long a cached resource, such as a single pokemon or species,
+	// is served without asking PokeAPI.
+	TTL time.Duration
+	// ListTTL is the TTL of list responses, such as the species count, which
+	// change whenever species are added.
+	ListTTL time.Duration
+}
+
+// cachingTransport serves GET requests from a ResponseCache.
+type cachingTransport struct {
+	next  http.RoundTripper
+	cache ResponseCache
+	cfg   CacheConfig
+	now   func() time.Time
+}
+
+// NewCachingTransport wraps next with a cache of successful GET responses.
+//
+// Responses younger than their TTL are served from the cache without a
+// request. Older ones are revalidated with If-None-Match and If-Modified-Since,
+// so an unchanged resource costs a 304 Not Modified instead of its body. A
+// zero TTL revalidates every request. Failing cache reads and writes are
+// logged and the request goes to PokeAPI. Whether a request was a cache hit, a
+// revalidation or a miss is recorded as an event on the span of the request
+// context.
+func NewCachingTransport(next http.RoundTripper, cache ResponseCache, cfg CacheConfig) http.RoundTripper {
+	return &cachingTransport{next: next, cache: cache, cfg: cfg, now: time.Now}
+}
+
+// RoundTrip answers the request from the cache where possible.
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req) //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+	}
+
+	ctx := req.Context()
+	url := req.URL.String()
+
+	cached, err := t.cache.GetResponse(ctx, url)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			slog.WarnContext(ctx, "failed to read cached pokeapi response",
+				slog.String("url", url),
+				slog.Any("error", err),
+			)
+		}
+
+		recordCacheResult(ctx, cacheResultMiss)
+
+		return t.fetch(req, url)
+	}
+
+	if t.now().Sub(cached.ValidatedAt) < t.ttl(req) {
+		recordCacheResult(ctx, cacheResultHit)
+
+		return cached.response(req), nil
+	}
+
+	return t.revalidate(req, cached)
+}
+
+// revalidate asks PokeAPI whether the cached response is still current.
+func (t *cachingTransport) revalidate(req *http.Request, cached CachedResponse) (*http.Response, error) {
+	ctx := req.Context()
+
+	conditional := req.Clone(ctx)
+	if cached.ETag != "" {
+		conditional.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	if cached.LastModified != "" {
+		conditional.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	resp, err := t.next.RoundTrip(conditional)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+	}
+
+	if resp.StatusCode != http.StatusNotModified {
+		recordCacheResult(ctx, cacheResultMiss)
+
+		return t.store(req, cached.URL, resp)
+	}
+
+	discardBody(resp)
+	recordCacheResult(ctx, cacheResultRevalidated)
+
+	err = t.cache.MarkResponseValidated(ctx, cached.URL, t.now())
+	if err != nil {
+		slog.WarnContext(ctx, "failed to mark cached pokeapi response as validated",
+			slog.String("url", cached.URL),
+			slog.Any("error", err),
+		)
+	}
+
+	return cached.response(req), nil
+}
+
+// fetch sends the request and caches a successful response.
+func (t *cachingTransport) fetch(req *http.Request, url string) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+	}
+
+	return t.store(req, url, resp)
+}
+
+// store caches the response if it succeeded and hands it on with a body that
+// can still be read.
+func (t *cachingTransport) store(req *http.Request, url string, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("reading pokeapi response: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = t.cache.PutResponse(req.Context(), CachedResponse{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentType:  resp.Header.Get("Content-Type"),
+		Body:         body,
+		ValidatedAt:  t.now(),
+	})
+	if err != nil {
+		slog.WarnContext(req.Context(), "failed to cache pokeapi response",
+			slog.String("url", url),
+			slog.Any("error", err),
+		)
+	}
+
+	return resp, nil
+}
+
+// ttl returns the TTL of the requested resource. Resources end in their ID;
+// everything else is a list.
+func (t *cachingTransport) ttl(req *http.Request) time.Duration {
+	_, err := strconv.Atoi(path.Base(req.URL.Path))
+	if err != nil {
+		return t.cfg.ListTTL
+	}
+
+	return t.cfg.TTL
+}
+
+// response builds the response served from the cache.
+func (c CachedResponse) response(req *http.Request) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", c.ContentType)
+
+	if c.ETag != "" {
+		header.Set("ETag", c.ETag)
+	}
+
+	if c.LastModified != "" {
+		header.Set("Last-Modified", c.LastModified)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+// CacheEvictor evicts cached responses that were not fetched or revalidated
+// within a maximum age, so responses of resources no import asks for anymore
+// do not pile up.
+type CacheEvictor struct {
+	cache    ResponseCache
+	maxAge   time.Duration
+	interval time.Duration
+	stop     context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewCacheEvictor creates an evictor of the responses older than maxAge. It
+// evicts at most once an hour, or once per maxAge if that is shorter.
+func NewCacheEvictor(cache ResponseCache, maxAge time.Duration) *CacheEvictor {
+	return &CacheEvictor{
+		cache:    cache,
+		maxAge:   maxAge,
+		interval: min(maxAge, cacheEvictionInterval),
+	}
+}
+
+// Start evicts expired responses in the background until Shutdown is called.
+// A zero maxAge keeps every response, so nothing is started.
+func (e *CacheEvictor) Start(ctx context.Context) {
+	if e.maxAge <= 0 {
+		return
+	}
+
+	ctx, e.stop = context.WithCancel(ctx)
+
+	e.wg.Go(func() {
+		e.run(ctx)
+	})
+}
+
+// Shutdown stops the evictor and waits for an in-flight eviction to finish.
+func (e *CacheEvictor) Shutdown() {
+	if e.stop != nil {
+		e.stop()
+	}
+
+	e.wg.Wait()
+}
+
+func (e *CacheEvictor) run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.evict(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evict deletes the responses older than the max age.
+func (e *CacheEvictor) evict(ctx context.Context) {
+	evicted, err := e.cache.DeleteResponsesValidatedBefore(ctx, time.Now().Add(-e.maxAge))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to evict expired pokeapi responses", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	if evicted > 0 {
+		slog.InfoContext(ctx, "evicted expired pokeapi responses", slog.Int64("count", evicted))
+	}
+}
+
+// recordCacheResult adds a cache event to the span of the request context.
+func recordCacheResult(ctx context.Context, result string) {
+	trace.SpanFromContext(ctx).AddEvent(cacheEventName,
+		trace.WithAttributes(attribute.String("pokeapi.cache.result", result)),
+	)
+}
@@ -0,0 +1,275 @@
+package pokeapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reference-service-go/internal/outgoing/pokeapi"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+)
+
+func TestCachingTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("serves fresh responses from the cache", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a caching transport with a long TTL
+		server := newETagServer(t, `{"id": 1}`)
+		cache := newMemoryCache()
+		transport := pokeapi.NewCachingTransport(http.DefaultTransport, cache, pokeapi.CacheConfig{TTL: time.Hour})
+
+		// when: requesting the same pokemon twice
+		first := readResponse(t, transport, server.URL+"/pokemon/1")
+		second := readResponse(t, transport, server.URL+"/pokemon/1")
+
+		// then: only the first request reaches the server
+		testastic.Equal(t, `{"id": 1}`, first)
+		testastic.Equal(t, `{"id": 1}`, second)
+		testastic.Equal(t, int32(1), server.requests.Load())
+		testastic.Equal(t, int32(1), server.full.Load())
+	})
+
+	t.Run("revalidates stale responses", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a caching transport that revalidates every request
+		server := newETagServer(t, `{"id": 1}`)
+		cache := newMemoryCache()
+		transport := pokeapi.NewCachingTransport(http.DefaultTransport, cache, pokeapi.CacheConfig{})
+
+		readResponse(t, transport, server.URL+"/pokemon/1")
+		validatedAt := cache.get(server.URL + "/pokemon/1").ValidatedAt
+
+		// when: requesting the unchanged pokemon again
+		body := readResponse(t, transport, server.URL+"/pokemon/1")
+
+		// then: the server answers not modified and the cached body is served
+		testastic.Equal(t, `{"id": 1}`, body)
+		testastic.Equal(t, int32(2), server.requests.Load())
+		testastic.Equal(t, int32(1), server.full.Load())
+		testastic.True(t, cache.get(server.URL+"/pokemon/1").ValidatedAt.After(validatedAt))
+	})
+
+	t.Run("replaces changed responses", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a cached pokemon that changed upstream
+		server := newETagServer(t, `{"id": 1}`)
+		cache := newMemoryCache()
+		transport := pokeapi.NewCachingTransport(http.DefaultTransport, cache, pokeapi.CacheConfig{})
+
+		readResponse(t, transport, server.URL+"/pokemon/1")
+		server.setBody(`{"id": 1, "changed": true}`)
+
+		// when: requesting the pokemon again
+		body := readResponse(t, transport, server.URL+"/pokemon/1")
+
+		// then: the new body is served and cached
+		testastic.Equal(t, `{"id": 1, "changed": true}`, body)
+		testastic.Equal(t, `{"id": 1, "changed": true}`, string(cache.get(server.URL+"/pokemon/1").Body))
+	})
+
+	t.Run("applies the list ttl to lists", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a caching transport that keeps resources for an hour but revalidates lists
+		server := newETagServer(t, `{"count": 1}`)
+		cache := newMemoryCache()
+		transport := pokeapi.NewCachingTransport(http.DefaultTransport, cache, pokeapi.CacheConfig{TTL: time.Hour})
+
+		// when: requesting the species list twice
+		readResponse(t, transport, server.URL+"/pokemon-species?limit=0")
+		readResponse(t, transport, server.URL+"/pokemon-species?limit=0")
+
+		// then: the second request is revalidated
+		testastic.Equal(t, int32(2), server.requests.Load())
+		testastic.Equal(t, int32(1), server.full.Load())
+	})
+
+	t.Run("does not cache failed responses", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that does not know the pokemon
+		server, hits := newFlakyServer(t, 10, http.StatusNotFound, "")
+		cache := newMemoryCache()
+		transport := pokeapi.NewCachingTransport(http.DefaultTransport, cache, pokeapi.CacheConfig{TTL: time.Hour})
+
+		// when: requesting the pokemon twice
+		doRequest(t.Context(), t, transport, server.URL+"/pokemon/1")
+		resp := doRequest(t.Context(), t, transport, server.URL+"/pokemon/1")
+
+		// then: both requests reach the server
+		testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+		testastic.Equal(t, int32(2), hits.Load())
+	})
+}
+
+func TestCacheEvictor(t *testing.T) {
+	t.Parallel()
+
+	// given: a cache holding a response validated two days ago and one validated just now
+	cache := newMemoryCache()
+
+	now := time.Now()
+	testastic.NoError(t, cache.PutResponse(t.Context(), pokeapi.CachedResponse{
+		URL:         "https://pokeapi.co/api/v2/pokemon/1",
+		ValidatedAt: now.Add(-48 * time.Hour),
+	}))
+	testastic.NoError(t, cache.PutResponse(t.Context(), pokeapi.CachedResponse{
+		URL:         "https://pokeapi.co/api/v2/pokemon/2",
+		ValidatedAt: now,
+	}))
+
+	// when: an evictor with a max age of a day is started
+	evictor := pokeapi.NewCacheEvictor(cache, 24*time.Hour)
+	evictor.Start(t.Context())
+
+	t.Cleanup(evictor.Shutdown)
+
+	// then: only the expired response is evicted
+	testastic.EventuallyEqual(t, 1, cache.len, time.Second)
+	testastic.True(t, cache.get("https://pokeapi.co/api/v2/pokemon/1").ValidatedAt.IsZero())
+	testastic.False(t, cache.get("https://pokeapi.co/api/v2/pokemon/2").ValidatedAt.IsZero())
+}
+
+// etagServer serves a body with an ETag and answers matching conditional
+// requests with 304 Not Modified.
+type etagServer struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	body string
+	etag int
+	// requests counts all requests, full those answered with a body.
+	requests atomic.Int32
+	full     atomic.Int32
+}
+
+func newETagServer(t *testing.T, body string) *etagServer {
+	t.Helper()
+
+	server := &etagServer{body: body, etag: 1}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests.Add(1)
+
+		server.mu.Lock()
+		body, etag := server.body, strconv.Quote(strconv.Itoa(server.etag))
+		server.mu.Unlock()
+
+		w.Header().Set("ETag", etag)
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		server.full.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *etagServer) setBody(body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.body = body
+	s.etag++
+}
+
+// memoryCache is an in-memory pokeapi.ResponseCache.
+type memoryCache struct {
+	mu        sync.Mutex
+	responses map[string]pokeapi.CachedResponse
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{responses: make(map[string]pokeapi.CachedResponse)}
+}
+
+func (c *memoryCache) GetResponse(_ context.Context, url string) (pokeapi.CachedResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp, ok := c.responses[url]
+	if !ok {
+		return pokeapi.CachedResponse{}, pokeapi.ErrCacheMiss
+	}
+
+	return resp, nil
+}
+
+func (c *memoryCache) PutResponse(_ context.Context, resp pokeapi.CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses[resp.URL] = resp
+
+	return nil
+}
+
+func (c *memoryCache) MarkResponseValidated(_ context.Context, url string, at time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := c.responses[url]
+	resp.ValidatedAt = at
+	c.responses[url] = resp
+
+	return nil
+}
+
+func (c *memoryCache) DeleteResponsesValidatedBefore(_ context.Context, before time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted int64
+
+	for url, resp := range c.responses {
+		if resp.ValidatedAt.Before(before) {
+			delete(c.responses, url)
+
+			evicted++
+		}
+	}
+
+	return evicted, nil
+}
+
+func (c *memoryCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.responses)
+}
+
+func (c *memoryCache) get(url string) pokeapi.CachedResponse {
+	resp, _ := c.GetResponse(context.Background(), url)
+
+	return resp
+}
+
+func readResponse(t *testing.T, transport http.RoundTripper, url string) string {
+	t.Helper()
+
+	resp := doRequest(t.Context(), t, transport, url)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	testastic.NoError(t, err)
+
+	return string(body)
+}
@@ -2,7 +2,10 @@
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"reference-service-go/internal/core/pokemon"
 	"strconv"
@@ -45,40 +48,69 @@ func (f *Fetcher) FetchSpeciesCount(ctx context.Context) (int, error) {
 }
 
 // FetchPokemon fetches a Pokemon by ID and maps it to a core Pokemon.
+//
+// Failures are returned as a *pokemon.FetchError so imports can report why a
+// species was skipped.
 func (f *Fetcher) FetchPokemon(ctx context.Context, id int) (*pokemon.Pokemon, error) {
 	idStr := strconv.Itoa(id)
 
 	pokemonResp, err := f.client.GetPokemonWithResponse(ctx, idStr)
 	if err != nil {
-		return nil, fmt.Errorf("fetching pokemon %d: %w", id, err)
+		return nil, classifyRequestError(fmt.Errorf("fetching pokemon %d: %w", id, err))
 	}
 
 	if pokemonResp.JSON200 == nil {
-		//nolint:err113 // Dynamic HTTP status.
-		return nil, fmt.Errorf(
-			"unexpected status %s for pokemon %d",
-			pokemonResp.Status(),
-			id,
-		)
+		return nil, &pokemon.FetchError{
+			Class:      pokemon.ItemErrorClassHTTPStatus,
+			HTTPStatus: pokemonResp.StatusCode(),
+			//nolint:err113 // Dynamic HTTP status.
+			Err: fmt.Errorf("unexpected status %s for pokemon %d", pokemonResp.Status(), id),
+		}
 	}
 
 	speciesResp, err := f.client.GetPokemonSpeciesWithResponse(ctx, idStr)
 	if err != nil {
-		return nil, fmt.Errorf("fetching species %d: %w", id, err)
+		return nil, classifyRequestError(fmt.Errorf("fetching species %d: %w", id, err))
 	}
 
 	if speciesResp.JSON200 == nil {
-		//nolint:err113 // Dynamic HTTP status.
-		return nil, fmt.Errorf(
-			"unexpected status %s for species %d",
-			speciesResp.Status(),
-			id,
-		)
+		return nil, &pokemon.FetchError{
+			Class:      pokemon.ItemErrorClassHTTPStatus,
+			HTTPStatus: speciesResp.StatusCode(),
+			//nolint:err113 // Dynamic HTTP status.
+			Err: fmt.Errorf("unexpected status %s for species %d", speciesResp.Status(), id),
+		}
 	}
 
 	return mapToPokemon(pokemonResp.JSON200, speciesResp.JSON200), nil
 }
 
+// classifyRequestError wraps a failed request as a *pokemon.FetchError.
+// Responses that do not decode are mapping errors; everything else is a
+// timeout or a transport error. Requests rejected by the circuit breaker mean
+// the source is unavailable rather than a single species.
+func classifyRequestError(err error) error {
+	if errors.Is(err, ErrCircuitOpen) {
+		return fmt.Errorf("%w: %w", pokemon.ErrSourceUnavailable, err)
+	}
+
+	var (
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		netErr       net.Error
+		itemErrClass = pokemon.ItemErrorClassTransport
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		itemErrClass = pokemon.ItemErrorClassMapping
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		itemErrClass = pokemon.ItemErrorClassTimeout
+	}
+
+	return &pokemon.FetchError{Class: itemErrClass, Err: err}
+}
+
 func mapToPokemon(detail *PokemonDetail, species *PokemonSpeciesDetail) *pokemon.Pokemon {
 	types := make([]string, 0, len(detail.Types))
 	for _, typeEntry := range detail.Types {
@@ -0,0 +1,221 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// retryEventName names the span event recorded for every retried request.
+const retryEventName = "pokeapi.retry"
+
+// TransportConfig configures the rate limiting and retries of NewTransport.
+//
+// A zero RateLimit disables rate limiting and a zero MaxRetries disables
+// retries.
+type TransportConfig struct {
+	// RateLimit is the number of requests per second, with bursts of up to Burst.
+	RateLimit float64
+	Burst     int
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// RetryBaseDelay is the backoff ceiling of the first retry. It doubles with
+	// every retry, up to RetryMaxDelay.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// transport rate limits and retries requests to PokeAPI.
+type transport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	cfg     TransportConfig
+}
+
+// NewTransport wraps next with a token-bucket rate limiter and retries.
+//
+// Every attempt waits for a token of the limiter, which is shared by all
+// requests through the transport. Responses with status 429 or a transient 5xx
+// and transport errors are retried with exponential backoff and full jitter,
+// or after the delay of the Retry-After header. A retry that would not start
+// before the request deadline is not attempted. Every retry is recorded as an
+// event on the span of the request context, which is the client span when the
+// transport is wrapped by otelhttp.
+func NewTransport(next http.RoundTripper, cfg TransportConfig) http.RoundTripper {
+	limit := rate.Inf
+	if cfg.RateLimit > 0 {
+		limit = rate.Limit(cfg.RateLimit)
+	}
+
+	return &transport{next: next, limiter: rate.NewLimiter(limit, cfg.Burst), cfg: cfg}
+}
+
+// RoundTrip sends the request, retrying it while the response is retryable.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		err := t.limiter.Wait(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for rate limiter: %w", err)
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt > t.cfg.MaxRetries || !retryable(req, resp, err) {
+			return resp, err //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+		}
+
+		delay := t.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err //nolint:wrapcheck // The client wraps transport errors in a *url.Error.
+		}
+
+		recordRetry(ctx, attempt, delay, resp, err)
+		discardBody(resp)
+
+		err = sleep(ctx, delay)
+		if err != nil {
+			return nil, fmt.Errorf("waiting to retry: %w", err)
+		}
+
+		req, err = rewindBody(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns how long to wait before the given retry. The Retry-After
+// header of the response takes precedence over the exponential backoff.
+func (t *transport) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if ok {
+			return delay
+		}
+	}
+
+	ceiling := t.cfg.RetryBaseDelay
+	for i := 1; i < retry && ceiling < t.cfg.RetryMaxDelay; i++ {
+		ceiling *= 2
+	}
+
+	ceiling = min(ceiling, t.cfg.RetryMaxDelay)
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling + 1) //nolint:gosec // Backoff jitter does not require crypto randomness.
+}
+
+// retryable reports whether a request that got the given response or error
+// should be sent again.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil || req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(at.Sub(now), 0), true
+}
+
+// recordRetry adds a retry event to the span of the request context.
+func recordRetry(ctx context.Context, retry int, delay time.Duration, resp *http.Response, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.Int("http.request.resend_count", retry),
+		attribute.Int64("pokeapi.retry.delay_ms", delay.Milliseconds()),
+	}
+
+	if err != nil {
+		attrs = append(attrs, attribute.String("error.message", err.Error()))
+	} else {
+		attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+	}
+
+	trace.SpanFromContext(ctx).AddEvent(retryEventName, trace.WithAttributes(attrs...))
+}
+
+// discardBody drains and closes the body of a response that is retried so the
+// connection can be reused.
+func discardBody(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// rewindBody returns a copy of the request with a fresh body to send again.
+func rewindBody(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("rewinding request body: %w", err)
+	}
+
+	rewound := req.Clone(req.Context())
+	rewound.Body = body
+
+	return rewound, nil
+}
+
+// sleep waits for the delay or until the context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
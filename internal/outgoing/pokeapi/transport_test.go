@@ -0,0 +1,166 @@
+package pokeapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reference-service-go/internal/outgoing/pokeapi"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	retries := pokeapi.TransportConfig{
+		MaxRetries:     3,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  5 * time.Millisecond,
+	}
+
+	t.Run("retries transient failures and records span events", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that fails twice before answering
+		server, hits := newFlakyServer(t, 2, http.StatusServiceUnavailable, "")
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+		ctx, span := tracer.Start(t.Context(), "fetch")
+
+		// when: sending a request through the transport
+		resp := doRequest(ctx, t, pokeapi.NewTransport(http.DefaultTransport, retries), server.URL)
+
+		span.End()
+
+		// then: it succeeds on the third attempt and records both retries
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, int32(3), hits.Load())
+
+		events := recorder.Ended()[0].Events()
+		testastic.Equal(t, 2, len(events))
+		testastic.Equal(t, "pokeapi.retry", events[0].Name)
+	})
+
+	t.Run("returns the last response once retries are exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that keeps failing
+		server, hits := newFlakyServer(t, 10, http.StatusBadGateway, "")
+
+		// when: sending a request through the transport
+		resp := doRequest(t.Context(), t, pokeapi.NewTransport(http.DefaultTransport, retries), server.URL)
+
+		// then: it gives up after the configured retries
+		testastic.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		testastic.Equal(t, int32(4), hits.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that answers not found
+		server, hits := newFlakyServer(t, 10, http.StatusNotFound, "")
+
+		// when: sending a request through the transport
+		resp := doRequest(t.Context(), t, pokeapi.NewTransport(http.DefaultTransport, retries), server.URL)
+
+		// then: it returns the response without retrying
+		testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+		testastic.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("waits for the retry-after delay", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that asks to retry after a second
+		server, hits := newFlakyServer(t, 1, http.StatusTooManyRequests, "1")
+		start := time.Now()
+
+		// when: sending a request through the transport
+		resp := doRequest(t.Context(), t, pokeapi.NewTransport(http.DefaultTransport, retries), server.URL)
+
+		// then: it retries after the requested delay instead of the backoff
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, int32(2), hits.Load())
+		testastic.True(t, time.Since(start) >= time.Second)
+	})
+
+	t.Run("does not retry past the request deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that asks to retry after a minute and a request deadline of a second
+		server, hits := newFlakyServer(t, 1, http.StatusTooManyRequests, "60")
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+
+		// when: sending a request through the transport
+		resp := doRequest(ctx, t, pokeapi.NewTransport(http.DefaultTransport, retries), server.URL)
+
+		// then: it returns the rate limited response right away
+		testastic.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		testastic.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("rate limits requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a transport allowing ten requests per second without bursts
+		server, _ := newFlakyServer(t, 0, http.StatusOK, "")
+		transport := pokeapi.NewTransport(http.DefaultTransport, pokeapi.TransportConfig{RateLimit: 10, Burst: 1})
+		start := time.Now()
+
+		// when: sending three requests
+		for range 3 {
+			doRequest(t.Context(), t, transport, server.URL)
+		}
+
+		// then: the second and third request wait for a token
+		testastic.True(t, time.Since(start) >= 150*time.Millisecond)
+	})
+}
+
+// newFlakyServer starts a server that answers the first failures requests with
+// the given status and Retry-After header, and every later request with 200.
+func newFlakyServer(t *testing.T, failures int32, status int, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hits.Add(1) > failures {
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &hits
+}
+
+func doRequest(ctx context.Context, t *testing.T, transport http.RoundTripper, url string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	testastic.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	testastic.NoError(t, err)
+
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE imports
+    ADD COLUMN lease_owner           TEXT,
+    ADD COLUMN lease_expires_at      TIMESTAMPTZ,
+    ADD COLUMN checkpoint_pokedex_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_imports_queue ON imports (created_at) WHERE status IN ('pending', 'processing');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_imports_queue;
+
+ALTER TABLE imports
+    DROP COLUMN IF EXISTS checkpoint_pokedex_id,
+    DROP COLUMN IF EXISTS lease_expires_at,
+    DROP COLUMN IF EXISTS lease_owner;
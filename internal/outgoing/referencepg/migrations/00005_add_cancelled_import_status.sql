@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE imports DROP CONSTRAINT imports_status_check;
+
+ALTER TABLE imports ADD CONSTRAINT imports_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'cancelled'));
+
+-- +goose Down
+UPDATE imports SET status = 'failed' WHERE status = 'cancelled';
+
+ALTER TABLE imports DROP CONSTRAINT imports_status_check;
+
+ALTER TABLE imports ADD CONSTRAINT imports_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_imports_created_at ON imports (created_at DESC, id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_imports_created_at;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE imports ADD COLUMN failed_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE import_errors (
+    import_id   UUID NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+    pokedex_id  INTEGER NOT NULL,
+    error_class TEXT NOT NULL CHECK (error_class IN ('http_status', 'timeout', 'mapping', 'transport')),
+    http_status INTEGER,
+    message     TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (import_id, pokedex_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_errors;
+
+ALTER TABLE imports DROP COLUMN IF EXISTS failed_count;
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE imports
+    ADD COLUMN parent_import_id UUID REFERENCES imports (id),
+    ADD COLUMN pokedex_ids      INTEGER[] NOT NULL DEFAULT '{}',
+    ADD COLUMN from_pokedex_id  INTEGER DEFAULT 1,
+    ADD COLUMN to_pokedex_id    INTEGER;
+
+-- +goose Down
+ALTER TABLE imports
+    DROP COLUMN IF EXISTS to_pokedex_id,
+    DROP COLUMN IF EXISTS from_pokedex_id,
+    DROP COLUMN IF EXISTS pokedex_ids,
+    DROP COLUMN IF EXISTS parent_import_id;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE pokemon ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE imports
+    ADD COLUMN mode            TEXT NOT NULL DEFAULT 'full' CHECK (mode IN ('full', 'incremental')),
+    ADD COLUMN inserted_count  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN updated_count   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN unchanged_count INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE imports
+    DROP COLUMN IF EXISTS unchanged_count,
+    DROP COLUMN IF EXISTS updated_count,
+    DROP COLUMN IF EXISTS inserted_count,
+    DROP COLUMN IF EXISTS mode;
+
+ALTER TABLE pokemon DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE import_uploads (
+    import_id       UUID NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+    pokedex_id      INTEGER NOT NULL,
+    name            TEXT NOT NULL,
+    rarity          TEXT NOT NULL CHECK (rarity IN ('common', 'uncommon', 'rare', 'legendary', 'mythical')),
+    types           TEXT[] NOT NULL,
+    sprite_url      TEXT NOT NULL DEFAULT '',
+    hp              INTEGER NOT NULL,
+    attack          INTEGER NOT NULL,
+    defense         INTEGER NOT NULL,
+    special_attack  INTEGER NOT NULL,
+    special_defense INTEGER NOT NULL,
+    speed           INTEGER NOT NULL,
+    base_experience INTEGER NOT NULL DEFAULT 0,
+    capture_rate    INTEGER NOT NULL DEFAULT 0,
+    is_legendary    BOOLEAN NOT NULL DEFAULT FALSE,
+    is_mythical     BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (import_id, pokedex_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_uploads;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE import_schedule_runs (
+    name       TEXT PRIMARY KEY,
+    last_tick  TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_schedule_runs;
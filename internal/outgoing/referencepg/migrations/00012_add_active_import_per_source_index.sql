@@ -0,0 +1,17 @@
+-- +goose Up
+-- Keep only the oldest in-flight import of each source; it is the one most
+-- likely to be processing already.
+UPDATE imports SET status = 'cancelled', updated_at = NOW()
+WHERE status IN ('pending', 'processing')
+  AND id NOT IN (
+    SELECT DISTINCT ON (source) id
+    FROM imports
+    WHERE status IN ('pending', 'processing')
+    ORDER BY source, created_at, id
+  );
+
+CREATE UNIQUE INDEX idx_imports_active_source ON imports (source)
+    WHERE status IN ('pending', 'processing');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_imports_active_source;
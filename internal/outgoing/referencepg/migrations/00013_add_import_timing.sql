@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE imports ADD COLUMN expected_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE imports ADD COLUMN started_at TIMESTAMPTZ;
+ALTER TABLE imports ADD COLUMN finished_at TIMESTAMPTZ;
+
+UPDATE imports SET finished_at = updated_at WHERE status IN ('completed', 'failed', 'cancelled');
+
+-- +goose Down
+ALTER TABLE imports DROP COLUMN IF EXISTS finished_at;
+ALTER TABLE imports DROP COLUMN IF EXISTS started_at;
+ALTER TABLE imports DROP COLUMN IF EXISTS expected_count;
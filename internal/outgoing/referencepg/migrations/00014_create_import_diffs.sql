@@ -0,0 +1,29 @@
+-- +goose Up
+ALTER TABLE imports DROP CONSTRAINT imports_mode_check;
+
+ALTER TABLE imports ADD CONSTRAINT imports_mode_check
+    CHECK (mode IN ('full', 'incremental', 'dry_run'));
+
+CREATE TABLE import_diffs (
+    import_id  UUID NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+    pokedex_id INTEGER NOT NULL,
+    name       TEXT NOT NULL,
+    kind       TEXT NOT NULL CHECK (kind IN ('new', 'removed', 'changed', 'unchanged')),
+    changes    JSONB NOT NULL DEFAULT '[]',
+    PRIMARY KEY (import_id, pokedex_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_diffs;
+
+-- Dry runs never wrote to the catalog, so nothing is lost with them. Their
+-- retries reference them as parent and are detached first.
+UPDATE imports SET parent_import_id = NULL
+WHERE parent_import_id IN (SELECT id FROM imports WHERE mode = 'dry_run');
+
+DELETE FROM imports WHERE mode = 'dry_run';
+
+ALTER TABLE imports DROP CONSTRAINT imports_mode_check;
+
+ALTER TABLE imports ADD CONSTRAINT imports_mode_check
+    CHECK (mode IN ('full', 'incremental'));
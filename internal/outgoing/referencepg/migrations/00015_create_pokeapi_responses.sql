@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE pokeapi_responses (
+    url           TEXT PRIMARY KEY,
+    etag          TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    content_type  TEXT NOT NULL DEFAULT '',
+    body          BYTEA NOT NULL,
+    validated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_pokeapi_responses_validated_at ON pokeapi_responses (validated_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS pokeapi_responses;
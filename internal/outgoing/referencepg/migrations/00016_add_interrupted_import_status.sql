@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE imports DROP CONSTRAINT imports_status_check;
+
+ALTER TABLE imports ADD CONSTRAINT imports_status_check
+    CHECK (status IN ('pending', 'processing', 'interrupted', 'completed', 'failed', 'cancelled'));
+
+-- An interrupted import is still in flight: it blocks new imports of its
+-- source and is claimed again like a pending one.
+DROP INDEX IF EXISTS idx_imports_active_source;
+
+CREATE UNIQUE INDEX idx_imports_active_source ON imports (source)
+    WHERE status IN ('pending', 'processing', 'interrupted');
+
+DROP INDEX IF EXISTS idx_imports_queue;
+
+CREATE INDEX idx_imports_queue ON imports (created_at) WHERE status IN ('pending', 'processing', 'interrupted');
+
+-- +goose Down
+UPDATE imports SET status = 'pending' WHERE status = 'interrupted';
+
+DROP INDEX IF EXISTS idx_imports_queue;
+
+CREATE INDEX idx_imports_queue ON imports (created_at) WHERE status IN ('pending', 'processing');
+
+DROP INDEX IF EXISTS idx_imports_active_source;
+
+CREATE UNIQUE INDEX idx_imports_active_source ON imports (source)
+    WHERE status IN ('pending', 'processing');
+
+ALTER TABLE imports DROP CONSTRAINT imports_status_check;
+
+ALTER TABLE imports ADD CONSTRAINT imports_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'cancelled'));
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE imports ADD COLUMN resume_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE imports ADD COLUMN failure_message TEXT;
+
+-- +goose Down
+ALTER TABLE imports DROP COLUMN IF EXISTS failure_message;
+ALTER TABLE imports DROP COLUMN IF EXISTS resume_count;
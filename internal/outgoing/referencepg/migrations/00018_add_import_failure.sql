@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE imports ADD COLUMN failure_phase TEXT
+    CHECK (failure_phase IN ('species_count', 'fetch', 'upsert', 'recovery'));
+ALTER TABLE imports ADD COLUMN failure_persisted_count INTEGER;
+
+-- +goose Down
+ALTER TABLE imports DROP COLUMN IF EXISTS failure_persisted_count;
+ALTER TABLE imports DROP COLUMN IF EXISTS failure_phase;
@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE pokemon ADD COLUMN last_seen_import_id UUID;
+ALTER TABLE pokemon ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_pokemon_active_rarity ON pokemon (rarity) WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_pokemon_active_rarity;
+ALTER TABLE pokemon DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE pokemon DROP COLUMN IF EXISTS last_seen_import_id;
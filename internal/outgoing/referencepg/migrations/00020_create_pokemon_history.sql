@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE pokemon_history (
+    id         BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    pokedex_id INTEGER NOT NULL,
+    import_id  UUID NOT NULL REFERENCES imports (id) ON DELETE CASCADE,
+    changes    JSONB NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pokemon_history_pokedex_id ON pokemon_history (pokedex_id, id DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS pokemon_history;
@@ -1,83 +1,375 @@
--- name: UpsertPokemon :exec
-INSERT INTO pokemon (
-    pokedex_id, name, rarity, types, sprite_url,
-    hp, attack, defense, special_attack, special_defense, speed,
-    base_experience, capture_rate, is_legendary, is_mythical
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-ON CONFLICT (pokedex_id) DO UPDATE SET
-    name = EXCLUDED.name,
-    rarity = EXCLUDED.rarity,
-    types = EXCLUDED.types,
-    sprite_url = EXCLUDED.sprite_url,
-    hp = EXCLUDED.hp,
-    attack = EXCLUDED.attack,
-    defense = EXCLUDED.defense,
-    special_attack = EXCLUDED.special_attack,
-    special_defense = EXCLUDED.special_defense,
-    speed = EXCLUDED.speed,
-    base_experience = EXCLUDED.base_experience,
-    capture_rate = EXCLUDED.capture_rate,
-    is_legendary = EXCLUDED.is_legendary,
-    is_mythical = EXCLUDED.is_mythical,
-    updated_at = NOW();
-
 -- name: GetPokemonByID :one
 SELECT pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
 FROM pokemon
 WHERE pokedex_id = $1;
 
+-- name: ListPokemonByIDs :many
+SELECT pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical,
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
+FROM pokemon
+WHERE pokedex_id = ANY(sqlc.arg(pokedex_ids)::int[])
+ORDER BY pokedex_id;
+
+-- name: LockPokemonByIDs :many
+SELECT pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical,
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
+FROM pokemon
+WHERE pokedex_id = ANY(sqlc.arg(pokedex_ids)::int[])
+ORDER BY pokedex_id
+FOR UPDATE;
+
 -- name: ListPokemon :many
 SELECT pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
 FROM pokemon
+WHERE sqlc.arg(include_inactive)::boolean OR deleted_at IS NULL
 ORDER BY pokedex_id
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
 
 -- name: ListPokemonByRarity :many
 SELECT pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
 FROM pokemon
-WHERE rarity = $1
+WHERE rarity = sqlc.arg(rarity) AND (sqlc.arg(include_inactive)::boolean OR deleted_at IS NULL)
 ORDER BY pokedex_id
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
 
 -- name: CountPokemon :one
-SELECT COUNT(*) FROM pokemon;
+SELECT COUNT(*) FROM pokemon WHERE sqlc.arg(include_inactive)::boolean OR deleted_at IS NULL;
+
+-- name: LockPokemonForSeeding :exec
+LOCK TABLE pokemon IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: RecordPokemonChanges :copyfrom
+INSERT INTO pokemon_history (pokedex_id, import_id, changes)
+VALUES ($1, $2, $3);
+
+-- name: ListPokemonHistory :many
+SELECT pokedex_id, import_id, changes, changed_at
+FROM pokemon_history
+WHERE pokedex_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountPokemonHistory :one
+SELECT COUNT(*) FROM pokemon_history WHERE pokedex_id = $1;
 
 -- name: CountPokemonByRarity :one
-SELECT COUNT(*) FROM pokemon WHERE rarity = $1;
+SELECT COUNT(*) FROM pokemon
+WHERE rarity = sqlc.arg(rarity) AND (sqlc.arg(include_inactive)::boolean OR deleted_at IS NULL);
 
 -- name: GetRandomPokemonByRarity :one
 SELECT pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
 FROM pokemon
-WHERE rarity = $1
+WHERE rarity = $1 AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
--- name: CreateImport :exec
-INSERT INTO imports (id, source, status, item_count, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+-- name: DeactivateUnseenPokemon :execrows
+-- Species the import failed to fetch for a transient reason were not missed by the source, so they
+-- stay active. A species the source answered with 404 Not Found was removed upstream.
+UPDATE pokemon
+SET deleted_at = NOW()
+WHERE deleted_at IS NULL
+    AND last_seen_import_id IS DISTINCT FROM sqlc.arg(import_id)
+    AND NOT EXISTS (
+        SELECT 1 FROM import_errors
+        WHERE import_errors.import_id = sqlc.arg(import_id) AND import_errors.pokedex_id = pokemon.pokedex_id
+            AND import_errors.http_status IS DISTINCT FROM 404
+    );
+
+-- name: CreateImport :execrows
+-- Inserts nothing while another import of the source is in flight, without
+-- aborting the transaction it runs in.
+INSERT INTO imports (
+    id, source, status, item_count, created_at, updated_at,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id, mode
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (source) WHERE status IN ('pending', 'processing', 'interrupted') DO NOTHING;
 
 -- name: GetImport :one
-SELECT id, source, status, item_count, created_at, updated_at
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
 FROM imports
 WHERE id = $1;
 
--- name: UpdateImportStatus :exec
+-- name: GetActiveImportBySource :one
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+FROM imports
+WHERE source = $1 AND status IN ('pending', 'processing', 'interrupted');
+
+-- name: ListImports :many
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+FROM imports
+WHERE (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+    AND (sqlc.narg(source)::text IS NULL OR source = sqlc.narg(source)::text)
+    AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after)::timestamptz)
+    AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before)::timestamptz)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountImports :one
+SELECT COUNT(*)
+FROM imports
+WHERE (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+    AND (sqlc.narg(source)::text IS NULL OR source = sqlc.narg(source)::text)
+    AND (sqlc.narg(created_after)::timestamptz IS NULL OR created_at >= sqlc.narg(created_after)::timestamptz)
+    AND (sqlc.narg(created_before)::timestamptz IS NULL OR created_at < sqlc.narg(created_before)::timestamptz);
+
+-- name: ClaimImport :one
+UPDATE imports
+SET resume_count = resume_count + CASE WHEN status = 'processing' THEN 1 ELSE 0 END,
+    status = 'processing',
+    started_at = COALESCE(started_at, NOW()),
+    lease_owner = sqlc.arg(lease_owner),
+    lease_expires_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::float8),
+    updated_at = NOW()
+WHERE id = (
+    SELECT queued.id
+    FROM imports AS queued
+    WHERE queued.status IN ('pending', 'interrupted')
+        OR (queued.status = 'processing' AND queued.lease_expires_at < NOW()
+            AND queued.resume_count < sqlc.arg(max_resumes))
+    ORDER BY queued.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count;
+
+-- name: RenewImportLease :execrows
+UPDATE imports
+SET lease_expires_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::float8),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND lease_owner = sqlc.arg(lease_owner) AND status = 'processing';
+
+-- name: UpdateImportProgress :execrows
+UPDATE imports
+SET item_count = sqlc.arg(item_count),
+    checkpoint_pokedex_id = sqlc.arg(checkpoint_pokedex_id),
+    inserted_count = sqlc.arg(inserted_count),
+    updated_count = sqlc.arg(updated_count),
+    unchanged_count = sqlc.arg(unchanged_count),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND lease_owner = sqlc.arg(lease_owner) AND status IN ('processing', 'cancelled');
+
+-- name: UpdateImportExpectedCount :execrows
+UPDATE imports
+SET expected_count = sqlc.arg(expected_count),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND lease_owner = sqlc.arg(lease_owner) AND status = 'processing';
+
+-- name: CancelImport :one
+UPDATE imports
+SET status = 'cancelled', finished_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status IN ('pending', 'processing', 'interrupted')
+RETURNING id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count;
+
+-- name: FinishImport :execrows
+UPDATE imports
+SET status = sqlc.arg(status),
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND lease_owner = sqlc.arg(lease_owner) AND status = 'processing';
+
+-- name: FailImport :execrows
 UPDATE imports
-SET status = $2, item_count = $3, updated_at = NOW()
+SET status = 'failed',
+    failure_phase = sqlc.arg(failure_phase),
+    failure_message = sqlc.arg(failure_message),
+    failure_persisted_count = item_count,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND lease_owner = sqlc.arg(lease_owner) AND status = 'processing';
+
+-- name: InterruptImport :execrows
+UPDATE imports
+SET status = 'interrupted',
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND lease_owner = sqlc.arg(lease_owner) AND status = 'processing';
+
+-- name: ListStaleImports :many
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+FROM imports
+WHERE status = 'processing'
+    AND updated_at < sqlc.arg(updated_before)
+    AND (lease_expires_at IS NULL OR lease_expires_at < NOW())
+ORDER BY created_at;
+
+-- name: ResumeStaleImport :execrows
+UPDATE imports
+SET status = 'interrupted',
+    resume_count = resume_count + 1,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'processing' AND updated_at = sqlc.arg(updated_at);
+
+-- name: FailStaleImport :execrows
+UPDATE imports
+SET status = 'failed',
+    failure_phase = 'recovery',
+    failure_message = sqlc.arg(failure_message),
+    failure_persisted_count = item_count,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'processing' AND updated_at = sqlc.arg(updated_at);
+
+-- name: RecordImportError :execrows
+INSERT INTO import_errors (import_id, pokedex_id, error_class, http_status, message)
+SELECT id, sqlc.arg(pokedex_id)::integer, sqlc.arg(error_class)::text,
+    sqlc.narg(http_status)::integer, sqlc.arg(message)::text
+FROM imports
+WHERE id = sqlc.arg(import_id) AND lease_owner = sqlc.arg(lease_owner) AND status IN ('processing', 'cancelled')
+ON CONFLICT (import_id, pokedex_id) DO UPDATE SET
+    error_class = EXCLUDED.error_class,
+    http_status = EXCLUDED.http_status,
+    message = EXCLUDED.message,
+    created_at = NOW();
+
+-- name: DeleteImportErrorsAfter :exec
+DELETE FROM import_errors
+WHERE import_id = sqlc.arg(import_id) AND pokedex_id > sqlc.arg(after_pokedex_id);
+
+-- name: RefreshImportFailedCount :exec
+UPDATE imports
+SET failed_count = (SELECT COUNT(*) FROM import_errors WHERE import_errors.import_id = imports.id),
+    updated_at = NOW()
 WHERE id = $1;
 
+-- name: ListImportErrorIDs :many
+SELECT pokedex_id
+FROM import_errors
+WHERE import_id = $1
+ORDER BY pokedex_id;
+
+-- name: ListImportErrors :many
+SELECT import_id, pokedex_id, error_class, http_status, message, created_at
+FROM import_errors
+WHERE import_id = $1
+ORDER BY pokedex_id
+LIMIT $2 OFFSET $3;
+
+-- name: RecordImportDiff :exec
+INSERT INTO import_diffs (import_id, pokedex_id, name, kind, changes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (import_id, pokedex_id) DO UPDATE SET
+    name = EXCLUDED.name,
+    kind = EXCLUDED.kind,
+    changes = EXCLUDED.changes;
+
+-- name: RecordRemovedImportDiffs :exec
+INSERT INTO import_diffs (import_id, pokedex_id, name, kind)
+SELECT sqlc.arg(import_id), pokemon.pokedex_id, pokemon.name, 'removed'
+FROM pokemon
+WHERE pokemon.deleted_at IS NULL
+    AND NOT EXISTS (
+    SELECT 1 FROM import_diffs
+    WHERE import_diffs.import_id = sqlc.arg(import_id) AND import_diffs.pokedex_id = pokemon.pokedex_id
+)
+    AND NOT EXISTS (
+        SELECT 1 FROM import_errors
+        WHERE import_errors.import_id = sqlc.arg(import_id) AND import_errors.pokedex_id = pokemon.pokedex_id
+            AND import_errors.http_status IS DISTINCT FROM 404
+    );
+
+-- name: ListImportDiffs :many
+SELECT import_id, pokedex_id, name, kind, changes
+FROM import_diffs
+WHERE import_id = sqlc.arg(import_id)
+    AND (kind = sqlc.narg(kind)::text OR (sqlc.narg(kind)::text IS NULL AND kind <> 'unchanged'))
+ORDER BY pokedex_id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountImportDiffsByKind :many
+SELECT kind, COUNT(*) AS count
+FROM import_diffs
+WHERE import_id = $1
+GROUP BY kind;
+
+-- name: CreateImportUploads :copyfrom
+INSERT INTO import_uploads (
+    import_id, pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
+
+-- name: GetUploadedPokemon :one
+SELECT import_id, pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical
+FROM import_uploads
+WHERE import_id = $1 AND pokedex_id = $2;
+
+-- name: ListUploadedPokemon :many
+SELECT import_id, pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical
+FROM import_uploads
+WHERE import_id = sqlc.arg(import_id) AND pokedex_id = ANY(sqlc.arg(pokedex_ids)::int[])
+ORDER BY pokedex_id;
+
+-- name: TryLockImportSchedule :one
+SELECT pg_try_advisory_xact_lock(hashtext('import_schedule'), hashtext(sqlc.arg(name)::text))::boolean AS locked;
+
+-- name: ClaimImportScheduleTick :one
+INSERT INTO import_schedule_runs (name, last_tick, updated_at)
+VALUES (sqlc.arg(name), sqlc.arg(tick), NOW())
+ON CONFLICT (name) DO UPDATE
+SET last_tick = EXCLUDED.last_tick, updated_at = EXCLUDED.updated_at
+WHERE import_schedule_runs.last_tick < EXCLUDED.last_tick
+RETURNING name;
+
 -- name: CreateCatch :exec
 INSERT INTO catches (id, pokemon_pokedex_id, pokeball_type, is_shiny, caught_at)
 VALUES ($1, $2, $3, $4, $5);
@@ -87,7 +379,31 @@ SELECT catches.id, catches.pokeball_type, catches.is_shiny, catches.caught_at,
     pokemon.pokedex_id, pokemon.name, pokemon.rarity, pokemon.types, pokemon.sprite_url,
     pokemon.hp, pokemon.attack, pokemon.defense, pokemon.special_attack, pokemon.special_defense, pokemon.speed,
     pokemon.base_experience, pokemon.capture_rate, pokemon.is_legendary, pokemon.is_mythical,
-    pokemon.created_at, pokemon.updated_at
+    pokemon.created_at, pokemon.updated_at, pokemon.deleted_at
 FROM catches
 JOIN pokemon ON pokemon.pokedex_id = catches.pokemon_pokedex_id
 WHERE catches.id = $1;
+
+-- name: GetPokeAPIResponse :one
+SELECT url, etag, last_modified, content_type, body, validated_at
+FROM pokeapi_responses
+WHERE url = $1;
+
+-- name: PutPokeAPIResponse :exec
+INSERT INTO pokeapi_responses (url, etag, last_modified, content_type, body, validated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (url) DO UPDATE
+SET etag = EXCLUDED.etag,
+    last_modified = EXCLUDED.last_modified,
+    content_type = EXCLUDED.content_type,
+    body = EXCLUDED.body,
+    validated_at = EXCLUDED.validated_at;
+
+-- name: MarkPokeAPIResponseValidated :exec
+UPDATE pokeapi_responses
+SET validated_at = sqlc.arg(validated_at)
+WHERE url = sqlc.arg(url);
+
+-- name: DeletePokeAPIResponsesValidatedBefore :execrows
+DELETE FROM pokeapi_responses
+WHERE validated_at < sqlc.arg(validated_before);
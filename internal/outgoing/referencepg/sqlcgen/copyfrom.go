@@ -0,0 +1,91 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: copyfrom.go
+
+package sqlcgen
+
+import (
+	"context"
+)
+
+// iteratorForCreateImportUploads implements pgx.CopyFromSource.
+type iteratorForCreateImportUploads struct {
+	rows                 []CreateImportUploadsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateImportUploads) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateImportUploads) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ImportID,
+		r.rows[0].PokedexID,
+		r.rows[0].Name,
+		r.rows[0].Rarity,
+		r.rows[0].Types,
+		r.rows[0].SpriteUrl,
+		r.rows[0].Hp,
+		r.rows[0].Attack,
+		r.rows[0].Defense,
+		r.rows[0].SpecialAttack,
+		r.rows[0].SpecialDefense,
+		r.rows[0].Speed,
+		r.rows[0].BaseExperience,
+		r.rows[0].CaptureRate,
+		r.rows[0].IsLegendary,
+		r.rows[0].IsMythical,
+	}, nil
+}
+
+func (r iteratorForCreateImportUploads) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateImportUploads(ctx context.Context, arg []CreateImportUploadsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"import_uploads"}, []string{"import_id", "pokedex_id", "name", "rarity", "types", "sprite_url", "hp", "attack", "defense", "special_attack", "special_defense", "speed", "base_experience", "capture_rate", "is_legendary", "is_mythical"}, &iteratorForCreateImportUploads{rows: arg})
+}
+
+// iteratorForRecordPokemonChanges implements pgx.CopyFromSource.
+type iteratorForRecordPokemonChanges struct {
+	rows                 []RecordPokemonChangesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForRecordPokemonChanges) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForRecordPokemonChanges) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].PokedexID,
+		r.rows[0].ImportID,
+		r.rows[0].Changes,
+	}, nil
+}
+
+func (r iteratorForRecordPokemonChanges) Err() error {
+	return nil
+}
+
+func (q *Queries) RecordPokemonChanges(ctx context.Context, arg []RecordPokemonChangesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"pokemon_history"}, []string{"pokedex_id", "import_id", "changes"}, &iteratorForRecordPokemonChanges{rows: arg})
+}
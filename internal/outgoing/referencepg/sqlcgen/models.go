@@ -1,6 +1,6 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.30.0
+//   sqlc v1.31.1
 
 package sqlcgen
 
@@ -17,30 +17,111 @@ type Catch struct {
 }
 
 type Import struct {
-	ID        pgtype.UUID        `json:"id"`
-	Source    string             `json:"source"`
-	Status    string             `json:"status"`
-	ItemCount int32              `json:"item_count"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ID                    pgtype.UUID        `json:"id"`
+	Source                string             `json:"source"`
+	Status                string             `json:"status"`
+	ItemCount             int32              `json:"item_count"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	LeaseOwner            pgtype.Text        `json:"lease_owner"`
+	LeaseExpiresAt        pgtype.Timestamptz `json:"lease_expires_at"`
+	CheckpointPokedexID   int32              `json:"checkpoint_pokedex_id"`
+	FailedCount           int32              `json:"failed_count"`
+	ParentImportID        pgtype.UUID        `json:"parent_import_id"`
+	PokedexIds            []int32            `json:"pokedex_ids"`
+	FromPokedexID         pgtype.Int4        `json:"from_pokedex_id"`
+	ToPokedexID           pgtype.Int4        `json:"to_pokedex_id"`
+	Mode                  string             `json:"mode"`
+	InsertedCount         int32              `json:"inserted_count"`
+	UpdatedCount          int32              `json:"updated_count"`
+	UnchangedCount        int32              `json:"unchanged_count"`
+	ExpectedCount         int32              `json:"expected_count"`
+	StartedAt             pgtype.Timestamptz `json:"started_at"`
+	FinishedAt            pgtype.Timestamptz `json:"finished_at"`
+	ResumeCount           int32              `json:"resume_count"`
+	FailureMessage        pgtype.Text        `json:"failure_message"`
+	FailurePhase          pgtype.Text        `json:"failure_phase"`
+	FailurePersistedCount pgtype.Int4        `json:"failure_persisted_count"`
+}
+
+type ImportDiff struct {
+	ImportID  pgtype.UUID `json:"import_id"`
+	PokedexID int32       `json:"pokedex_id"`
+	Name      string      `json:"name"`
+	Kind      string      `json:"kind"`
+	Changes   []byte      `json:"changes"`
+}
+
+type ImportError struct {
+	ImportID   pgtype.UUID        `json:"import_id"`
+	PokedexID  int32              `json:"pokedex_id"`
+	ErrorClass string             `json:"error_class"`
+	HttpStatus pgtype.Int4        `json:"http_status"`
+	Message    string             `json:"message"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type ImportScheduleRun struct {
+	Name      string             `json:"name"`
+	LastTick  pgtype.Timestamptz `json:"last_tick"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
+type ImportUpload struct {
+	ImportID       pgtype.UUID `json:"import_id"`
+	PokedexID      int32       `json:"pokedex_id"`
+	Name           string      `json:"name"`
+	Rarity         string      `json:"rarity"`
+	Types          []string    `json:"types"`
+	SpriteUrl      string      `json:"sprite_url"`
+	Hp             int32       `json:"hp"`
+	Attack         int32       `json:"attack"`
+	Defense        int32       `json:"defense"`
+	SpecialAttack  int32       `json:"special_attack"`
+	SpecialDefense int32       `json:"special_defense"`
+	Speed          int32       `json:"speed"`
+	BaseExperience int32       `json:"base_experience"`
+	CaptureRate    int32       `json:"capture_rate"`
+	IsLegendary    bool        `json:"is_legendary"`
+	IsMythical     bool        `json:"is_mythical"`
+}
+
+type PokeapiResponse struct {
+	Url          string             `json:"url"`
+	Etag         string             `json:"etag"`
+	LastModified string             `json:"last_modified"`
+	ContentType  string             `json:"content_type"`
+	Body         []byte             `json:"body"`
+	ValidatedAt  pgtype.Timestamptz `json:"validated_at"`
+}
+
 type Pokemon struct {
-	PokedexID      int32              `json:"pokedex_id"`
-	Name           string             `json:"name"`
-	Rarity         string             `json:"rarity"`
-	Types          []string           `json:"types"`
-	SpriteUrl      string             `json:"sprite_url"`
-	Hp             int32              `json:"hp"`
-	Attack         int32              `json:"attack"`
-	Defense        int32              `json:"defense"`
-	SpecialAttack  int32              `json:"special_attack"`
-	SpecialDefense int32              `json:"special_defense"`
-	Speed          int32              `json:"speed"`
-	BaseExperience int32              `json:"base_experience"`
-	CaptureRate    int32              `json:"capture_rate"`
-	IsLegendary    bool               `json:"is_legendary"`
-	IsMythical     bool               `json:"is_mythical"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	PokedexID        int32              `json:"pokedex_id"`
+	Name             string             `json:"name"`
+	Rarity           string             `json:"rarity"`
+	Types            []string           `json:"types"`
+	SpriteUrl        string             `json:"sprite_url"`
+	Hp               int32              `json:"hp"`
+	Attack           int32              `json:"attack"`
+	Defense          int32              `json:"defense"`
+	SpecialAttack    int32              `json:"special_attack"`
+	SpecialDefense   int32              `json:"special_defense"`
+	Speed            int32              `json:"speed"`
+	BaseExperience   int32              `json:"base_experience"`
+	CaptureRate      int32              `json:"capture_rate"`
+	IsLegendary      bool               `json:"is_legendary"`
+	IsMythical       bool               `json:"is_mythical"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	ContentHash      string             `json:"content_hash"`
+	LastSeenImportID pgtype.UUID        `json:"last_seen_import_id"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type PokemonHistory struct {
+	ID        int64              `json:"id"`
+	PokedexID int32              `json:"pokedex_id"`
+	ImportID  pgtype.UUID        `json:"import_id"`
+	Changes   []byte             `json:"changes"`
+	ChangedAt pgtype.Timestamptz `json:"changed_at"`
 }
@@ -1,6 +1,6 @@
 // Code generated by sqlc. DO NOT EDIT.
 // versions:
-//   sqlc v1.30.0
+//   sqlc v1.31.1
 // source: query.sql
 
 package sqlcgen
@@ -11,23 +11,231 @@
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelImport = `-- name: CancelImport :one
+UPDATE imports
+SET status = 'cancelled', finished_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status IN ('pending', 'processing', 'interrupted')
+RETURNING id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+`
+
+func (q *Queries) CancelImport(ctx context.Context, id pgtype.UUID) (Import, error) {
+	row := q.db.QueryRow(ctx, cancelImport, id)
+	var i Import
+	err := row.Scan(
+		&i.ID,
+		&i.Source,
+		&i.Status,
+		&i.ItemCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.CheckpointPokedexID,
+		&i.FailedCount,
+		&i.ParentImportID,
+		&i.PokedexIds,
+		&i.FromPokedexID,
+		&i.ToPokedexID,
+		&i.Mode,
+		&i.InsertedCount,
+		&i.UpdatedCount,
+		&i.UnchangedCount,
+		&i.ExpectedCount,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.ResumeCount,
+		&i.FailureMessage,
+		&i.FailurePhase,
+		&i.FailurePersistedCount,
+	)
+	return i, err
+}
+
+const claimImport = `-- name: ClaimImport :one
+UPDATE imports
+SET resume_count = resume_count + CASE WHEN status = 'processing' THEN 1 ELSE 0 END,
+    status = 'processing',
+    started_at = COALESCE(started_at, NOW()),
+    lease_owner = $1,
+    lease_expires_at = NOW() + make_interval(secs => $2::float8),
+    updated_at = NOW()
+WHERE id = (
+    SELECT queued.id
+    FROM imports AS queued
+    WHERE queued.status IN ('pending', 'interrupted')
+        OR (queued.status = 'processing' AND queued.lease_expires_at < NOW()
+            AND queued.resume_count < $3)
+    ORDER BY queued.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+`
+
+type ClaimImportParams struct {
+	LeaseOwner   pgtype.Text `json:"lease_owner"`
+	LeaseSeconds float64     `json:"lease_seconds"`
+	MaxResumes   int32       `json:"max_resumes"`
+}
+
+func (q *Queries) ClaimImport(ctx context.Context, arg ClaimImportParams) (Import, error) {
+	row := q.db.QueryRow(ctx, claimImport, arg.LeaseOwner, arg.LeaseSeconds, arg.MaxResumes)
+	var i Import
+	err := row.Scan(
+		&i.ID,
+		&i.Source,
+		&i.Status,
+		&i.ItemCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.CheckpointPokedexID,
+		&i.FailedCount,
+		&i.ParentImportID,
+		&i.PokedexIds,
+		&i.FromPokedexID,
+		&i.ToPokedexID,
+		&i.Mode,
+		&i.InsertedCount,
+		&i.UpdatedCount,
+		&i.UnchangedCount,
+		&i.ExpectedCount,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.ResumeCount,
+		&i.FailureMessage,
+		&i.FailurePhase,
+		&i.FailurePersistedCount,
+	)
+	return i, err
+}
+
+const claimImportScheduleTick = `-- name: ClaimImportScheduleTick :one
+INSERT INTO import_schedule_runs (name, last_tick, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (name) DO UPDATE
+SET last_tick = EXCLUDED.last_tick, updated_at = EXCLUDED.updated_at
+WHERE import_schedule_runs.last_tick < EXCLUDED.last_tick
+RETURNING name
+`
+
+type ClaimImportScheduleTickParams struct {
+	Name string             `json:"name"`
+	Tick pgtype.Timestamptz `json:"tick"`
+}
+
+func (q *Queries) ClaimImportScheduleTick(ctx context.Context, arg ClaimImportScheduleTickParams) (string, error) {
+	row := q.db.QueryRow(ctx, claimImportScheduleTick, arg.Name, arg.Tick)
+	var name string
+	err := row.Scan(&name)
+	return name, err
+}
+
+const countImportDiffsByKind = `-- name: CountImportDiffsByKind :many
+SELECT kind, COUNT(*) AS count
+FROM import_diffs
+WHERE import_id = $1
+GROUP BY kind
+`
+
+type CountImportDiffsByKindRow struct {
+	Kind  string `json:"kind"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) CountImportDiffsByKind(ctx context.Context, importID pgtype.UUID) ([]CountImportDiffsByKindRow, error) {
+	rows, err := q.db.Query(ctx, countImportDiffsByKind, importID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountImportDiffsByKindRow{}
+	for rows.Next() {
+		var i CountImportDiffsByKindRow
+		if err := rows.Scan(&i.Kind, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countImports = `-- name: CountImports :one
+SELECT COUNT(*)
+FROM imports
+WHERE ($1::text IS NULL OR status = $1::text)
+    AND ($2::text IS NULL OR source = $2::text)
+    AND ($3::timestamptz IS NULL OR created_at >= $3::timestamptz)
+    AND ($4::timestamptz IS NULL OR created_at < $4::timestamptz)
+`
+
+type CountImportsParams struct {
+	Status        pgtype.Text        `json:"status"`
+	Source        pgtype.Text        `json:"source"`
+	CreatedAfter  pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `json:"created_before"`
+}
+
+func (q *Queries) CountImports(ctx context.Context, arg CountImportsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countImports,
+		arg.Status,
+		arg.Source,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPokemon = `-- name: CountPokemon :one
-SELECT COUNT(*) FROM pokemon
+SELECT COUNT(*) FROM pokemon WHERE $1::boolean OR deleted_at IS NULL
 `
 
-func (q *Queries) CountPokemon(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countPokemon)
+func (q *Queries) CountPokemon(ctx context.Context, includeInactive bool) (int64, error) {
+	row := q.db.QueryRow(ctx, countPokemon, includeInactive)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
 const countPokemonByRarity = `-- name: CountPokemonByRarity :one
-SELECT COUNT(*) FROM pokemon WHERE rarity = $1
+SELECT COUNT(*) FROM pokemon
+WHERE rarity = $1 AND ($2::boolean OR deleted_at IS NULL)
+`
+
+type CountPokemonByRarityParams struct {
+	Rarity          string `json:"rarity"`
+	IncludeInactive bool   `json:"include_inactive"`
+}
+
+func (q *Queries) CountPokemonByRarity(ctx context.Context, arg CountPokemonByRarityParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPokemonByRarity, arg.Rarity, arg.IncludeInactive)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPokemonHistory = `-- name: CountPokemonHistory :one
+SELECT COUNT(*) FROM pokemon_history WHERE pokedex_id = $1
 `
 
-func (q *Queries) CountPokemonByRarity(ctx context.Context, rarity string) (int64, error) {
-	row := q.db.QueryRow(ctx, countPokemonByRarity, rarity)
+func (q *Queries) CountPokemonHistory(ctx context.Context, pokedexID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countPokemonHistory, pokedexID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -57,38 +265,253 @@ func (q *Queries) CreateCatch(ctx context.Context, arg CreateCatchParams) error
 	return err
 }
 
-const createImport = `-- name: CreateImport :exec
-INSERT INTO imports (id, source, status, item_count, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+const createImport = `-- name: CreateImport :execrows
+INSERT INTO imports (
+    id, source, status, item_count, created_at, updated_at,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id, mode
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (source) WHERE status IN ('pending', 'processing', 'interrupted') DO NOTHING
 `
 
 type CreateImportParams struct {
-	ID        pgtype.UUID        `json:"id"`
-	Source    string             `json:"source"`
-	Status    string             `json:"status"`
-	ItemCount int32              `json:"item_count"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID             pgtype.UUID        `json:"id"`
+	Source         string             `json:"source"`
+	Status         string             `json:"status"`
+	ItemCount      int32              `json:"item_count"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	ParentImportID pgtype.UUID        `json:"parent_import_id"`
+	PokedexIds     []int32            `json:"pokedex_ids"`
+	FromPokedexID  pgtype.Int4        `json:"from_pokedex_id"`
+	ToPokedexID    pgtype.Int4        `json:"to_pokedex_id"`
+	Mode           string             `json:"mode"`
 }
 
-func (q *Queries) CreateImport(ctx context.Context, arg CreateImportParams) error {
-	_, err := q.db.Exec(ctx, createImport,
+// Inserts nothing while another import of the source is in flight, without
+// aborting the transaction it runs in.
+func (q *Queries) CreateImport(ctx context.Context, arg CreateImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createImport,
 		arg.ID,
 		arg.Source,
 		arg.Status,
 		arg.ItemCount,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ParentImportID,
+		arg.PokedexIds,
+		arg.FromPokedexID,
+		arg.ToPokedexID,
+		arg.Mode,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+type CreateImportUploadsParams struct {
+	ImportID       pgtype.UUID `json:"import_id"`
+	PokedexID      int32       `json:"pokedex_id"`
+	Name           string      `json:"name"`
+	Rarity         string      `json:"rarity"`
+	Types          []string    `json:"types"`
+	SpriteUrl      string      `json:"sprite_url"`
+	Hp             int32       `json:"hp"`
+	Attack         int32       `json:"attack"`
+	Defense        int32       `json:"defense"`
+	SpecialAttack  int32       `json:"special_attack"`
+	SpecialDefense int32       `json:"special_defense"`
+	Speed          int32       `json:"speed"`
+	BaseExperience int32       `json:"base_experience"`
+	CaptureRate    int32       `json:"capture_rate"`
+	IsLegendary    bool        `json:"is_legendary"`
+	IsMythical     bool        `json:"is_mythical"`
+}
+
+const deactivateUnseenPokemon = `-- name: DeactivateUnseenPokemon :execrows
+UPDATE pokemon
+SET deleted_at = NOW()
+WHERE deleted_at IS NULL
+    AND last_seen_import_id IS DISTINCT FROM $1
+    AND NOT EXISTS (
+        SELECT 1 FROM import_errors
+        WHERE import_errors.import_id = $1 AND import_errors.pokedex_id = pokemon.pokedex_id
+            AND import_errors.http_status IS DISTINCT FROM 404
+    )
+`
+
+// Species the import failed to fetch for a transient reason were not missed by the source, so they
+// stay active. A species the source answered with 404 Not Found was removed upstream.
+func (q *Queries) DeactivateUnseenPokemon(ctx context.Context, importID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deactivateUnseenPokemon, importID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteImportErrorsAfter = `-- name: DeleteImportErrorsAfter :exec
+DELETE FROM import_errors
+WHERE import_id = $1 AND pokedex_id > $2
+`
+
+type DeleteImportErrorsAfterParams struct {
+	ImportID       pgtype.UUID `json:"import_id"`
+	AfterPokedexID int32       `json:"after_pokedex_id"`
+}
+
+func (q *Queries) DeleteImportErrorsAfter(ctx context.Context, arg DeleteImportErrorsAfterParams) error {
+	_, err := q.db.Exec(ctx, deleteImportErrorsAfter, arg.ImportID, arg.AfterPokedexID)
 	return err
 }
 
+const deletePokeAPIResponsesValidatedBefore = `-- name: DeletePokeAPIResponsesValidatedBefore :execrows
+DELETE FROM pokeapi_responses
+WHERE validated_at < $1
+`
+
+func (q *Queries) DeletePokeAPIResponsesValidatedBefore(ctx context.Context, validatedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePokeAPIResponsesValidatedBefore, validatedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const failImport = `-- name: FailImport :execrows
+UPDATE imports
+SET status = 'failed',
+    failure_phase = $1,
+    failure_message = $2,
+    failure_persisted_count = item_count,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $3 AND lease_owner = $4 AND status = 'processing'
+`
+
+type FailImportParams struct {
+	FailurePhase   pgtype.Text `json:"failure_phase"`
+	FailureMessage pgtype.Text `json:"failure_message"`
+	ID             pgtype.UUID `json:"id"`
+	LeaseOwner     pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) FailImport(ctx context.Context, arg FailImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, failImport,
+		arg.FailurePhase,
+		arg.FailureMessage,
+		arg.ID,
+		arg.LeaseOwner,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const failStaleImport = `-- name: FailStaleImport :execrows
+UPDATE imports
+SET status = 'failed',
+    failure_phase = 'recovery',
+    failure_message = $1,
+    failure_persisted_count = item_count,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $2 AND status = 'processing' AND updated_at = $3
+`
+
+type FailStaleImportParams struct {
+	FailureMessage pgtype.Text        `json:"failure_message"`
+	ID             pgtype.UUID        `json:"id"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) FailStaleImport(ctx context.Context, arg FailStaleImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, failStaleImport, arg.FailureMessage, arg.ID, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const finishImport = `-- name: FinishImport :execrows
+UPDATE imports
+SET status = $1,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $2 AND lease_owner = $3 AND status = 'processing'
+`
+
+type FinishImportParams struct {
+	Status     string      `json:"status"`
+	ID         pgtype.UUID `json:"id"`
+	LeaseOwner pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) FinishImport(ctx context.Context, arg FinishImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, finishImport, arg.Status, arg.ID, arg.LeaseOwner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getActiveImportBySource = `-- name: GetActiveImportBySource :one
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+FROM imports
+WHERE source = $1 AND status IN ('pending', 'processing', 'interrupted')
+`
+
+func (q *Queries) GetActiveImportBySource(ctx context.Context, source string) (Import, error) {
+	row := q.db.QueryRow(ctx, getActiveImportBySource, source)
+	var i Import
+	err := row.Scan(
+		&i.ID,
+		&i.Source,
+		&i.Status,
+		&i.ItemCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.CheckpointPokedexID,
+		&i.FailedCount,
+		&i.ParentImportID,
+		&i.PokedexIds,
+		&i.FromPokedexID,
+		&i.ToPokedexID,
+		&i.Mode,
+		&i.InsertedCount,
+		&i.UpdatedCount,
+		&i.UnchangedCount,
+		&i.ExpectedCount,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.ResumeCount,
+		&i.FailureMessage,
+		&i.FailurePhase,
+		&i.FailurePersistedCount,
+	)
+	return i, err
+}
+
 const getCatch = `-- name: GetCatch :one
 SELECT catches.id, catches.pokeball_type, catches.is_shiny, catches.caught_at,
     pokemon.pokedex_id, pokemon.name, pokemon.rarity, pokemon.types, pokemon.sprite_url,
     pokemon.hp, pokemon.attack, pokemon.defense, pokemon.special_attack, pokemon.special_defense, pokemon.speed,
     pokemon.base_experience, pokemon.capture_rate, pokemon.is_legendary, pokemon.is_mythical,
-    pokemon.created_at, pokemon.updated_at
+    pokemon.created_at, pokemon.updated_at, pokemon.deleted_at
 FROM catches
 JOIN pokemon ON pokemon.pokedex_id = catches.pokemon_pokedex_id
 WHERE catches.id = $1
@@ -116,6 +539,7 @@ type GetCatchRow struct {
 	IsMythical     bool               `json:"is_mythical"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt      pgtype.Timestamptz `json:"deleted_at"`
 }
 
 func (q *Queries) GetCatch(ctx context.Context, id pgtype.UUID) (GetCatchRow, error) {
@@ -143,12 +567,18 @@ func (q *Queries) GetCatch(ctx context.Context, id pgtype.UUID) (GetCatchRow, er
 		&i.IsMythical,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getImport = `-- name: GetImport :one
-SELECT id, source, status, item_count, created_at, updated_at
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
 FROM imports
 WHERE id = $1
 `
@@ -163,6 +593,45 @@ func (q *Queries) GetImport(ctx context.Context, id pgtype.UUID) (Import, error)
 		&i.ItemCount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LeaseOwner,
+		&i.LeaseExpiresAt,
+		&i.CheckpointPokedexID,
+		&i.FailedCount,
+		&i.ParentImportID,
+		&i.PokedexIds,
+		&i.FromPokedexID,
+		&i.ToPokedexID,
+		&i.Mode,
+		&i.InsertedCount,
+		&i.UpdatedCount,
+		&i.UnchangedCount,
+		&i.ExpectedCount,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.ResumeCount,
+		&i.FailureMessage,
+		&i.FailurePhase,
+		&i.FailurePersistedCount,
+	)
+	return i, err
+}
+
+const getPokeAPIResponse = `-- name: GetPokeAPIResponse :one
+SELECT url, etag, last_modified, content_type, body, validated_at
+FROM pokeapi_responses
+WHERE url = $1
+`
+
+func (q *Queries) GetPokeAPIResponse(ctx context.Context, url string) (PokeapiResponse, error) {
+	row := q.db.QueryRow(ctx, getPokeAPIResponse, url)
+	var i PokeapiResponse
+	err := row.Scan(
+		&i.Url,
+		&i.Etag,
+		&i.LastModified,
+		&i.ContentType,
+		&i.Body,
+		&i.ValidatedAt,
 	)
 	return i, err
 }
@@ -171,7 +640,7 @@ func (q *Queries) GetImport(ctx context.Context, id pgtype.UUID) (Import, error)
 SELECT pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
 FROM pokemon
 WHERE pokedex_id = $1
 `
@@ -197,6 +666,9 @@ func (q *Queries) GetPokemonByID(ctx context.Context, pokedexID int32) (Pokemon,
 		&i.IsMythical,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ContentHash,
+		&i.LastSeenImportID,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -205,9 +677,9 @@ func (q *Queries) GetPokemonByID(ctx context.Context, pokedexID int32) (Pokemon,
 SELECT pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
 FROM pokemon
-WHERE rarity = $1
+WHERE rarity = $1 AND deleted_at IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -233,52 +705,108 @@ func (q *Queries) GetRandomPokemonByRarity(ctx context.Context, rarity string) (
 		&i.IsMythical,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ContentHash,
+		&i.LastSeenImportID,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const listPokemon = `-- name: ListPokemon :many
-SELECT pokedex_id, name, rarity, types, sprite_url,
+const getUploadedPokemon = `-- name: GetUploadedPokemon :one
+SELECT import_id, pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
-    base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
-FROM pokemon
+    base_experience, capture_rate, is_legendary, is_mythical
+FROM import_uploads
+WHERE import_id = $1 AND pokedex_id = $2
+`
+
+type GetUploadedPokemonParams struct {
+	ImportID  pgtype.UUID `json:"import_id"`
+	PokedexID int32       `json:"pokedex_id"`
+}
+
+func (q *Queries) GetUploadedPokemon(ctx context.Context, arg GetUploadedPokemonParams) (ImportUpload, error) {
+	row := q.db.QueryRow(ctx, getUploadedPokemon, arg.ImportID, arg.PokedexID)
+	var i ImportUpload
+	err := row.Scan(
+		&i.ImportID,
+		&i.PokedexID,
+		&i.Name,
+		&i.Rarity,
+		&i.Types,
+		&i.SpriteUrl,
+		&i.Hp,
+		&i.Attack,
+		&i.Defense,
+		&i.SpecialAttack,
+		&i.SpecialDefense,
+		&i.Speed,
+		&i.BaseExperience,
+		&i.CaptureRate,
+		&i.IsLegendary,
+		&i.IsMythical,
+	)
+	return i, err
+}
+
+const interruptImport = `-- name: InterruptImport :execrows
+UPDATE imports
+SET status = 'interrupted',
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND lease_owner = $2 AND status = 'processing'
+`
+
+type InterruptImportParams struct {
+	ID         pgtype.UUID `json:"id"`
+	LeaseOwner pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) InterruptImport(ctx context.Context, arg InterruptImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, interruptImport, arg.ID, arg.LeaseOwner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listImportDiffs = `-- name: ListImportDiffs :many
+SELECT import_id, pokedex_id, name, kind, changes
+FROM import_diffs
+WHERE import_id = $1
+    AND (kind = $2::text OR ($2::text IS NULL AND kind <> 'unchanged'))
 ORDER BY pokedex_id
-LIMIT $1 OFFSET $2
+LIMIT $4 OFFSET $3
 `
 
-type ListPokemonParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type ListImportDiffsParams struct {
+	ImportID  pgtype.UUID `json:"import_id"`
+	Kind      pgtype.Text `json:"kind"`
+	RowOffset int32       `json:"row_offset"`
+	RowLimit  int32       `json:"row_limit"`
 }
 
-func (q *Queries) ListPokemon(ctx context.Context, arg ListPokemonParams) ([]Pokemon, error) {
-	rows, err := q.db.Query(ctx, listPokemon, arg.Limit, arg.Offset)
+func (q *Queries) ListImportDiffs(ctx context.Context, arg ListImportDiffsParams) ([]ImportDiff, error) {
+	rows, err := q.db.Query(ctx, listImportDiffs,
+		arg.ImportID,
+		arg.Kind,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Pokemon{}
+	items := []ImportDiff{}
 	for rows.Next() {
-		var i Pokemon
+		var i ImportDiff
 		if err := rows.Scan(
+			&i.ImportID,
 			&i.PokedexID,
 			&i.Name,
-			&i.Rarity,
-			&i.Types,
-			&i.SpriteUrl,
-			&i.Hp,
-			&i.Attack,
-			&i.Defense,
-			&i.SpecialAttack,
-			&i.SpecialDefense,
-			&i.Speed,
-			&i.BaseExperience,
-			&i.CaptureRate,
-			&i.IsLegendary,
-			&i.IsMythical,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Kind,
+			&i.Changes,
 		); err != nil {
 			return nil, err
 		}
@@ -290,38 +818,184 @@ func (q *Queries) ListPokemon(ctx context.Context, arg ListPokemonParams) ([]Pok
 	return items, nil
 }
 
-const listPokemonByRarity = `-- name: ListPokemonByRarity :many
-SELECT pokedex_id, name, rarity, types, sprite_url,
-    hp, attack, defense, special_attack, special_defense, speed,
-    base_experience, capture_rate, is_legendary, is_mythical,
-    created_at, updated_at
-FROM pokemon
-WHERE rarity = $1
+const listImportErrorIDs = `-- name: ListImportErrorIDs :many
+SELECT pokedex_id
+FROM import_errors
+WHERE import_id = $1
+ORDER BY pokedex_id
+`
+
+func (q *Queries) ListImportErrorIDs(ctx context.Context, importID pgtype.UUID) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listImportErrorIDs, importID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var pokedex_id int32
+		if err := rows.Scan(&pokedex_id); err != nil {
+			return nil, err
+		}
+		items = append(items, pokedex_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImportErrors = `-- name: ListImportErrors :many
+SELECT import_id, pokedex_id, error_class, http_status, message, created_at
+FROM import_errors
+WHERE import_id = $1
 ORDER BY pokedex_id
 LIMIT $2 OFFSET $3
 `
 
-type ListPokemonByRarityParams struct {
-	Rarity string `json:"rarity"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+type ListImportErrorsParams struct {
+	ImportID pgtype.UUID `json:"import_id"`
+	Limit    int32       `json:"limit"`
+	Offset   int32       `json:"offset"`
 }
 
-func (q *Queries) ListPokemonByRarity(ctx context.Context, arg ListPokemonByRarityParams) ([]Pokemon, error) {
-	rows, err := q.db.Query(ctx, listPokemonByRarity, arg.Rarity, arg.Limit, arg.Offset)
+func (q *Queries) ListImportErrors(ctx context.Context, arg ListImportErrorsParams) ([]ImportError, error) {
+	rows, err := q.db.Query(ctx, listImportErrors, arg.ImportID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Pokemon{}
+	items := []ImportError{}
 	for rows.Next() {
-		var i Pokemon
+		var i ImportError
 		if err := rows.Scan(
+			&i.ImportID,
 			&i.PokedexID,
-			&i.Name,
-			&i.Rarity,
-			&i.Types,
-			&i.SpriteUrl,
+			&i.ErrorClass,
+			&i.HttpStatus,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listImports = `-- name: ListImports :many
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+FROM imports
+WHERE ($1::text IS NULL OR status = $1::text)
+    AND ($2::text IS NULL OR source = $2::text)
+    AND ($3::timestamptz IS NULL OR created_at >= $3::timestamptz)
+    AND ($4::timestamptz IS NULL OR created_at < $4::timestamptz)
+ORDER BY created_at DESC, id DESC
+LIMIT $6 OFFSET $5
+`
+
+type ListImportsParams struct {
+	Status        pgtype.Text        `json:"status"`
+	Source        pgtype.Text        `json:"source"`
+	CreatedAfter  pgtype.Timestamptz `json:"created_after"`
+	CreatedBefore pgtype.Timestamptz `json:"created_before"`
+	RowOffset     int32              `json:"row_offset"`
+	RowLimit      int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListImports(ctx context.Context, arg ListImportsParams) ([]Import, error) {
+	rows, err := q.db.Query(ctx, listImports,
+		arg.Status,
+		arg.Source,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Import{}
+	for rows.Next() {
+		var i Import
+		if err := rows.Scan(
+			&i.ID,
+			&i.Source,
+			&i.Status,
+			&i.ItemCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.CheckpointPokedexID,
+			&i.FailedCount,
+			&i.ParentImportID,
+			&i.PokedexIds,
+			&i.FromPokedexID,
+			&i.ToPokedexID,
+			&i.Mode,
+			&i.InsertedCount,
+			&i.UpdatedCount,
+			&i.UnchangedCount,
+			&i.ExpectedCount,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.ResumeCount,
+			&i.FailureMessage,
+			&i.FailurePhase,
+			&i.FailurePersistedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPokemon = `-- name: ListPokemon :many
+SELECT pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical,
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
+FROM pokemon
+WHERE $1::boolean OR deleted_at IS NULL
+ORDER BY pokedex_id
+LIMIT $3 OFFSET $2
+`
+
+type ListPokemonParams struct {
+	IncludeInactive bool  `json:"include_inactive"`
+	RowOffset       int32 `json:"row_offset"`
+	RowLimit        int32 `json:"row_limit"`
+}
+
+func (q *Queries) ListPokemon(ctx context.Context, arg ListPokemonParams) ([]Pokemon, error) {
+	rows, err := q.db.Query(ctx, listPokemon, arg.IncludeInactive, arg.RowOffset, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Pokemon{}
+	for rows.Next() {
+		var i Pokemon
+		if err := rows.Scan(
+			&i.PokedexID,
+			&i.Name,
+			&i.Rarity,
+			&i.Types,
+			&i.SpriteUrl,
 			&i.Hp,
 			&i.Attack,
 			&i.Defense,
@@ -334,6 +1008,9 @@ func (q *Queries) ListPokemonByRarity(ctx context.Context, arg ListPokemonByRari
 			&i.IsMythical,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.LastSeenImportID,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -345,82 +1022,600 @@ func (q *Queries) ListPokemonByRarity(ctx context.Context, arg ListPokemonByRari
 	return items, nil
 }
 
-const updateImportStatus = `-- name: UpdateImportStatus :exec
-UPDATE imports
-SET status = $2, item_count = $3, updated_at = NOW()
-WHERE id = $1
+const listPokemonByIDs = `-- name: ListPokemonByIDs :many
+SELECT pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical,
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
+FROM pokemon
+WHERE pokedex_id = ANY($1::int[])
+ORDER BY pokedex_id
 `
 
-type UpdateImportStatusParams struct {
-	ID        pgtype.UUID `json:"id"`
-	Status    string      `json:"status"`
-	ItemCount int32       `json:"item_count"`
+func (q *Queries) ListPokemonByIDs(ctx context.Context, pokedexIds []int32) ([]Pokemon, error) {
+	rows, err := q.db.Query(ctx, listPokemonByIDs, pokedexIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Pokemon{}
+	for rows.Next() {
+		var i Pokemon
+		if err := rows.Scan(
+			&i.PokedexID,
+			&i.Name,
+			&i.Rarity,
+			&i.Types,
+			&i.SpriteUrl,
+			&i.Hp,
+			&i.Attack,
+			&i.Defense,
+			&i.SpecialAttack,
+			&i.SpecialDefense,
+			&i.Speed,
+			&i.BaseExperience,
+			&i.CaptureRate,
+			&i.IsLegendary,
+			&i.IsMythical,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.LastSeenImportID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) UpdateImportStatus(ctx context.Context, arg UpdateImportStatusParams) error {
-	_, err := q.db.Exec(ctx, updateImportStatus, arg.ID, arg.Status, arg.ItemCount)
-	return err
+const listPokemonByRarity = `-- name: ListPokemonByRarity :many
+SELECT pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical,
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
+FROM pokemon
+WHERE rarity = $1 AND ($2::boolean OR deleted_at IS NULL)
+ORDER BY pokedex_id
+LIMIT $4 OFFSET $3
+`
+
+type ListPokemonByRarityParams struct {
+	Rarity          string `json:"rarity"`
+	IncludeInactive bool   `json:"include_inactive"`
+	RowOffset       int32  `json:"row_offset"`
+	RowLimit        int32  `json:"row_limit"`
+}
+
+func (q *Queries) ListPokemonByRarity(ctx context.Context, arg ListPokemonByRarityParams) ([]Pokemon, error) {
+	rows, err := q.db.Query(ctx, listPokemonByRarity,
+		arg.Rarity,
+		arg.IncludeInactive,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Pokemon{}
+	for rows.Next() {
+		var i Pokemon
+		if err := rows.Scan(
+			&i.PokedexID,
+			&i.Name,
+			&i.Rarity,
+			&i.Types,
+			&i.SpriteUrl,
+			&i.Hp,
+			&i.Attack,
+			&i.Defense,
+			&i.SpecialAttack,
+			&i.SpecialDefense,
+			&i.Speed,
+			&i.BaseExperience,
+			&i.CaptureRate,
+			&i.IsLegendary,
+			&i.IsMythical,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.LastSeenImportID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPokemonHistory = `-- name: ListPokemonHistory :many
+SELECT pokedex_id, import_id, changes, changed_at
+FROM pokemon_history
+WHERE pokedex_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListPokemonHistoryParams struct {
+	PokedexID int32 `json:"pokedex_id"`
+	Limit     int32 `json:"limit"`
+	Offset    int32 `json:"offset"`
+}
+
+type ListPokemonHistoryRow struct {
+	PokedexID int32              `json:"pokedex_id"`
+	ImportID  pgtype.UUID        `json:"import_id"`
+	Changes   []byte             `json:"changes"`
+	ChangedAt pgtype.Timestamptz `json:"changed_at"`
+}
+
+func (q *Queries) ListPokemonHistory(ctx context.Context, arg ListPokemonHistoryParams) ([]ListPokemonHistoryRow, error) {
+	rows, err := q.db.Query(ctx, listPokemonHistory, arg.PokedexID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPokemonHistoryRow{}
+	for rows.Next() {
+		var i ListPokemonHistoryRow
+		if err := rows.Scan(
+			&i.PokedexID,
+			&i.ImportID,
+			&i.Changes,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const upsertPokemon = `-- name: UpsertPokemon :exec
-INSERT INTO pokemon (
-    pokedex_id, name, rarity, types, sprite_url,
+const listStaleImports = `-- name: ListStaleImports :many
+SELECT id, source, status, item_count, created_at, updated_at,
+    lease_owner, lease_expires_at, checkpoint_pokedex_id, failed_count,
+    parent_import_id, pokedex_ids, from_pokedex_id, to_pokedex_id,
+    mode, inserted_count, updated_count, unchanged_count,
+    expected_count, started_at, finished_at, resume_count, failure_message,
+    failure_phase, failure_persisted_count
+FROM imports
+WHERE status = 'processing'
+    AND updated_at < $1
+    AND (lease_expires_at IS NULL OR lease_expires_at < NOW())
+ORDER BY created_at
+`
+
+func (q *Queries) ListStaleImports(ctx context.Context, updatedBefore pgtype.Timestamptz) ([]Import, error) {
+	rows, err := q.db.Query(ctx, listStaleImports, updatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Import{}
+	for rows.Next() {
+		var i Import
+		if err := rows.Scan(
+			&i.ID,
+			&i.Source,
+			&i.Status,
+			&i.ItemCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LeaseOwner,
+			&i.LeaseExpiresAt,
+			&i.CheckpointPokedexID,
+			&i.FailedCount,
+			&i.ParentImportID,
+			&i.PokedexIds,
+			&i.FromPokedexID,
+			&i.ToPokedexID,
+			&i.Mode,
+			&i.InsertedCount,
+			&i.UpdatedCount,
+			&i.UnchangedCount,
+			&i.ExpectedCount,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.ResumeCount,
+			&i.FailureMessage,
+			&i.FailurePhase,
+			&i.FailurePersistedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUploadedPokemon = `-- name: ListUploadedPokemon :many
+SELECT import_id, pokedex_id, name, rarity, types, sprite_url,
     hp, attack, defense, special_attack, special_defense, speed,
     base_experience, capture_rate, is_legendary, is_mythical
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-ON CONFLICT (pokedex_id) DO UPDATE SET
+FROM import_uploads
+WHERE import_id = $1 AND pokedex_id = ANY($2::int[])
+ORDER BY pokedex_id
+`
+
+type ListUploadedPokemonParams struct {
+	ImportID   pgtype.UUID `json:"import_id"`
+	PokedexIds []int32     `json:"pokedex_ids"`
+}
+
+func (q *Queries) ListUploadedPokemon(ctx context.Context, arg ListUploadedPokemonParams) ([]ImportUpload, error) {
+	rows, err := q.db.Query(ctx, listUploadedPokemon, arg.ImportID, arg.PokedexIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportUpload{}
+	for rows.Next() {
+		var i ImportUpload
+		if err := rows.Scan(
+			&i.ImportID,
+			&i.PokedexID,
+			&i.Name,
+			&i.Rarity,
+			&i.Types,
+			&i.SpriteUrl,
+			&i.Hp,
+			&i.Attack,
+			&i.Defense,
+			&i.SpecialAttack,
+			&i.SpecialDefense,
+			&i.Speed,
+			&i.BaseExperience,
+			&i.CaptureRate,
+			&i.IsLegendary,
+			&i.IsMythical,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPokemonByIDs = `-- name: LockPokemonByIDs :many
+SELECT pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical,
+    created_at, updated_at, content_hash, last_seen_import_id, deleted_at
+FROM pokemon
+WHERE pokedex_id = ANY($1::int[])
+ORDER BY pokedex_id
+FOR UPDATE
+`
+
+func (q *Queries) LockPokemonByIDs(ctx context.Context, pokedexIds []int32) ([]Pokemon, error) {
+	rows, err := q.db.Query(ctx, lockPokemonByIDs, pokedexIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Pokemon{}
+	for rows.Next() {
+		var i Pokemon
+		if err := rows.Scan(
+			&i.PokedexID,
+			&i.Name,
+			&i.Rarity,
+			&i.Types,
+			&i.SpriteUrl,
+			&i.Hp,
+			&i.Attack,
+			&i.Defense,
+			&i.SpecialAttack,
+			&i.SpecialDefense,
+			&i.Speed,
+			&i.BaseExperience,
+			&i.CaptureRate,
+			&i.IsLegendary,
+			&i.IsMythical,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.LastSeenImportID,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPokemonForSeeding = `-- name: LockPokemonForSeeding :exec
+LOCK TABLE pokemon IN SHARE ROW EXCLUSIVE MODE
+`
+
+func (q *Queries) LockPokemonForSeeding(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockPokemonForSeeding)
+	return err
+}
+
+const markPokeAPIResponseValidated = `-- name: MarkPokeAPIResponseValidated :exec
+UPDATE pokeapi_responses
+SET validated_at = $1
+WHERE url = $2
+`
+
+type MarkPokeAPIResponseValidatedParams struct {
+	ValidatedAt pgtype.Timestamptz `json:"validated_at"`
+	Url         string             `json:"url"`
+}
+
+func (q *Queries) MarkPokeAPIResponseValidated(ctx context.Context, arg MarkPokeAPIResponseValidatedParams) error {
+	_, err := q.db.Exec(ctx, markPokeAPIResponseValidated, arg.ValidatedAt, arg.Url)
+	return err
+}
+
+const putPokeAPIResponse = `-- name: PutPokeAPIResponse :exec
+INSERT INTO pokeapi_responses (url, etag, last_modified, content_type, body, validated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (url) DO UPDATE
+SET etag = EXCLUDED.etag,
+    last_modified = EXCLUDED.last_modified,
+    content_type = EXCLUDED.content_type,
+    body = EXCLUDED.body,
+    validated_at = EXCLUDED.validated_at
+`
+
+type PutPokeAPIResponseParams struct {
+	Url          string             `json:"url"`
+	Etag         string             `json:"etag"`
+	LastModified string             `json:"last_modified"`
+	ContentType  string             `json:"content_type"`
+	Body         []byte             `json:"body"`
+	ValidatedAt  pgtype.Timestamptz `json:"validated_at"`
+}
+
+func (q *Queries) PutPokeAPIResponse(ctx context.Context, arg PutPokeAPIResponseParams) error {
+	_, err := q.db.Exec(ctx, putPokeAPIResponse,
+		arg.Url,
+		arg.Etag,
+		arg.LastModified,
+		arg.ContentType,
+		arg.Body,
+		arg.ValidatedAt,
+	)
+	return err
+}
+
+const recordImportDiff = `-- name: RecordImportDiff :exec
+INSERT INTO import_diffs (import_id, pokedex_id, name, kind, changes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (import_id, pokedex_id) DO UPDATE SET
     name = EXCLUDED.name,
-    rarity = EXCLUDED.rarity,
-    types = EXCLUDED.types,
-    sprite_url = EXCLUDED.sprite_url,
-    hp = EXCLUDED.hp,
-    attack = EXCLUDED.attack,
-    defense = EXCLUDED.defense,
-    special_attack = EXCLUDED.special_attack,
-    special_defense = EXCLUDED.special_defense,
-    speed = EXCLUDED.speed,
-    base_experience = EXCLUDED.base_experience,
-    capture_rate = EXCLUDED.capture_rate,
-    is_legendary = EXCLUDED.is_legendary,
-    is_mythical = EXCLUDED.is_mythical,
-    updated_at = NOW()
+    kind = EXCLUDED.kind,
+    changes = EXCLUDED.changes
 `
 
-type UpsertPokemonParams struct {
-	PokedexID      int32    `json:"pokedex_id"`
-	Name           string   `json:"name"`
-	Rarity         string   `json:"rarity"`
-	Types          []string `json:"types"`
-	SpriteUrl      string   `json:"sprite_url"`
-	Hp             int32    `json:"hp"`
-	Attack         int32    `json:"attack"`
-	Defense        int32    `json:"defense"`
-	SpecialAttack  int32    `json:"special_attack"`
-	SpecialDefense int32    `json:"special_defense"`
-	Speed          int32    `json:"speed"`
-	BaseExperience int32    `json:"base_experience"`
-	CaptureRate    int32    `json:"capture_rate"`
-	IsLegendary    bool     `json:"is_legendary"`
-	IsMythical     bool     `json:"is_mythical"`
-}
-
-func (q *Queries) UpsertPokemon(ctx context.Context, arg UpsertPokemonParams) error {
-	_, err := q.db.Exec(ctx, upsertPokemon,
+type RecordImportDiffParams struct {
+	ImportID  pgtype.UUID `json:"import_id"`
+	PokedexID int32       `json:"pokedex_id"`
+	Name      string      `json:"name"`
+	Kind      string      `json:"kind"`
+	Changes   []byte      `json:"changes"`
+}
+
+func (q *Queries) RecordImportDiff(ctx context.Context, arg RecordImportDiffParams) error {
+	_, err := q.db.Exec(ctx, recordImportDiff,
+		arg.ImportID,
 		arg.PokedexID,
 		arg.Name,
-		arg.Rarity,
-		arg.Types,
-		arg.SpriteUrl,
-		arg.Hp,
-		arg.Attack,
-		arg.Defense,
-		arg.SpecialAttack,
-		arg.SpecialDefense,
-		arg.Speed,
-		arg.BaseExperience,
-		arg.CaptureRate,
-		arg.IsLegendary,
-		arg.IsMythical,
+		arg.Kind,
+		arg.Changes,
+	)
+	return err
+}
+
+const recordImportError = `-- name: RecordImportError :execrows
+INSERT INTO import_errors (import_id, pokedex_id, error_class, http_status, message)
+SELECT id, $1::integer, $2::text,
+    $3::integer, $4::text
+FROM imports
+WHERE id = $5 AND lease_owner = $6 AND status IN ('processing', 'cancelled')
+ON CONFLICT (import_id, pokedex_id) DO UPDATE SET
+    error_class = EXCLUDED.error_class,
+    http_status = EXCLUDED.http_status,
+    message = EXCLUDED.message,
+    created_at = NOW()
+`
+
+type RecordImportErrorParams struct {
+	PokedexID  int32       `json:"pokedex_id"`
+	ErrorClass string      `json:"error_class"`
+	HttpStatus pgtype.Int4 `json:"http_status"`
+	Message    string      `json:"message"`
+	ImportID   pgtype.UUID `json:"import_id"`
+	LeaseOwner pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) RecordImportError(ctx context.Context, arg RecordImportErrorParams) (int64, error) {
+	result, err := q.db.Exec(ctx, recordImportError,
+		arg.PokedexID,
+		arg.ErrorClass,
+		arg.HttpStatus,
+		arg.Message,
+		arg.ImportID,
+		arg.LeaseOwner,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+type RecordPokemonChangesParams struct {
+	PokedexID int32       `json:"pokedex_id"`
+	ImportID  pgtype.UUID `json:"import_id"`
+	Changes   []byte      `json:"changes"`
+}
+
+const recordRemovedImportDiffs = `-- name: RecordRemovedImportDiffs :exec
+INSERT INTO import_diffs (import_id, pokedex_id, name, kind)
+SELECT $1, pokemon.pokedex_id, pokemon.name, 'removed'
+FROM pokemon
+WHERE pokemon.deleted_at IS NULL
+    AND NOT EXISTS (
+    SELECT 1 FROM import_diffs
+    WHERE import_diffs.import_id = $1 AND import_diffs.pokedex_id = pokemon.pokedex_id
+)
+    AND NOT EXISTS (
+        SELECT 1 FROM import_errors
+        WHERE import_errors.import_id = $1 AND import_errors.pokedex_id = pokemon.pokedex_id
+            AND import_errors.http_status IS DISTINCT FROM 404
+    )
+`
+
+func (q *Queries) RecordRemovedImportDiffs(ctx context.Context, importID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, recordRemovedImportDiffs, importID)
+	return err
+}
+
+const refreshImportFailedCount = `-- name: RefreshImportFailedCount :exec
+UPDATE imports
+SET failed_count = (SELECT COUNT(*) FROM import_errors WHERE import_errors.import_id = imports.id),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) RefreshImportFailedCount(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, refreshImportFailedCount, id)
 	return err
 }
+
+const renewImportLease = `-- name: RenewImportLease :execrows
+UPDATE imports
+SET lease_expires_at = NOW() + make_interval(secs => $1::float8),
+    updated_at = NOW()
+WHERE id = $2 AND lease_owner = $3 AND status = 'processing'
+`
+
+type RenewImportLeaseParams struct {
+	LeaseSeconds float64     `json:"lease_seconds"`
+	ID           pgtype.UUID `json:"id"`
+	LeaseOwner   pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) RenewImportLease(ctx context.Context, arg RenewImportLeaseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, renewImportLease, arg.LeaseSeconds, arg.ID, arg.LeaseOwner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resumeStaleImport = `-- name: ResumeStaleImport :execrows
+UPDATE imports
+SET status = 'interrupted',
+    resume_count = resume_count + 1,
+    lease_owner = NULL,
+    lease_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'processing' AND updated_at = $2
+`
+
+type ResumeStaleImportParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ResumeStaleImport(ctx context.Context, arg ResumeStaleImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resumeStaleImport, arg.ID, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const tryLockImportSchedule = `-- name: TryLockImportSchedule :one
+SELECT pg_try_advisory_xact_lock(hashtext('import_schedule'), hashtext($1::text))::boolean AS locked
+`
+
+func (q *Queries) TryLockImportSchedule(ctx context.Context, name string) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockImportSchedule, name)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
+}
+
+const updateImportExpectedCount = `-- name: UpdateImportExpectedCount :execrows
+UPDATE imports
+SET expected_count = $1,
+    updated_at = NOW()
+WHERE id = $2 AND lease_owner = $3 AND status = 'processing'
+`
+
+type UpdateImportExpectedCountParams struct {
+	ExpectedCount int32       `json:"expected_count"`
+	ID            pgtype.UUID `json:"id"`
+	LeaseOwner    pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) UpdateImportExpectedCount(ctx context.Context, arg UpdateImportExpectedCountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateImportExpectedCount, arg.ExpectedCount, arg.ID, arg.LeaseOwner)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateImportProgress = `-- name: UpdateImportProgress :execrows
+UPDATE imports
+SET item_count = $1,
+    checkpoint_pokedex_id = $2,
+    inserted_count = $3,
+    updated_count = $4,
+    unchanged_count = $5,
+    updated_at = NOW()
+WHERE id = $6 AND lease_owner = $7 AND status IN ('processing', 'cancelled')
+`
+
+type UpdateImportProgressParams struct {
+	ItemCount           int32       `json:"item_count"`
+	CheckpointPokedexID int32       `json:"checkpoint_pokedex_id"`
+	InsertedCount       int32       `json:"inserted_count"`
+	UpdatedCount        int32       `json:"updated_count"`
+	UnchangedCount      int32       `json:"unchanged_count"`
+	ID                  pgtype.UUID `json:"id"`
+	LeaseOwner          pgtype.Text `json:"lease_owner"`
+}
+
+func (q *Queries) UpdateImportProgress(ctx context.Context, arg UpdateImportProgressParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateImportProgress,
+		arg.ItemCount,
+		arg.CheckpointPokedexID,
+		arg.InsertedCount,
+		arg.UpdatedCount,
+		arg.UnchangedCount,
+		arg.ID,
+		arg.LeaseOwner,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
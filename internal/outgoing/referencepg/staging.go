@@ -0,0 +1,145 @@
+package referencepg
+
+import (
+	"context"
+	"fmt"
+	"reference-service-go/internal/core/pokemon"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// The staging table is temporary, so sqlc cannot check the statements below
+// against the schema and they are run on the transaction directly.
+const (
+	// createPokemonStaging creates a staging table shaped like pokemon that is
+	// dropped when the transaction ends.
+	createPokemonStaging = `CREATE TEMP TABLE pokemon_staging (LIKE pokemon INCLUDING DEFAULTS) ON COMMIT DROP`
+
+	// pokemonColumns lists the columns an upsert writes, in the order of
+	// pokemonRow.
+	pokemonColumns = `pokedex_id, name, rarity, types, sprite_url,
+    hp, attack, defense, special_attack, special_defense, speed,
+    base_experience, capture_rate, is_legendary, is_mythical, content_hash, last_seen_import_id`
+
+	// upsertPokemonConflict updates an existing species and revives it if it
+	// was deactivated. When $1 (skip unchanged) is set, species whose content
+	// hash matches are left untouched and return no row.
+	upsertPokemonConflict = `
+ON CONFLICT (pokedex_id) DO UPDATE SET
+    name = EXCLUDED.name,
+    rarity = EXCLUDED.rarity,
+    types = EXCLUDED.types,
+    sprite_url = EXCLUDED.sprite_url,
+    hp = EXCLUDED.hp,
+    attack = EXCLUDED.attack,
+    defense = EXCLUDED.defense,
+    special_attack = EXCLUDED.special_attack,
+    special_defense = EXCLUDED.special_defense,
+    speed = EXCLUDED.speed,
+    base_experience = EXCLUDED.base_experience,
+    capture_rate = EXCLUDED.capture_rate,
+    is_legendary = EXCLUDED.is_legendary,
+    is_mythical = EXCLUDED.is_mythical,
+    content_hash = EXCLUDED.content_hash,
+    last_seen_import_id = EXCLUDED.last_seen_import_id,
+    deleted_at = NULL,
+    updated_at = NOW()
+WHERE NOT $1::boolean OR pokemon.content_hash <> EXCLUDED.content_hash
+    OR pokemon.deleted_at IS NOT NULL
+RETURNING pokedex_id, (xmax = 0)::boolean AS inserted`
+
+	// mergePokemonStaging upserts every staged row into pokemon and returns
+	// the rows it wrote.
+	mergePokemonStaging = `
+INSERT INTO pokemon (` + pokemonColumns + `)
+SELECT ` + pokemonColumns + `
+FROM pokemon_staging
+ORDER BY pokedex_id` + upsertPokemonConflict
+)
+
+//nolint:gochecknoglobals // Column list of the staging COPY.
+var pokemonStagingColumns = []string{
+	"pokedex_id", "name", "rarity", "types", "sprite_url",
+	"hp", "attack", "defense", "special_attack", "special_defense", "speed",
+	"base_experience", "capture_rate", "is_legendary", "is_mythical", "content_hash", "last_seen_import_id",
+}
+
+// pokemonRow returns the values of p for pokemonColumns. A nil importID leaves
+// the row without a last seen import, as for a seeded catalog.
+func pokemonRow(p pokemon.Pokemon, importID *uuid.UUID) []any {
+	return []any{
+		int32(p.PokedexID), //nolint:gosec // Pokedex IDs are small positive ints.
+		p.Name,
+		string(p.Rarity),
+		p.Types,
+		p.SpriteURL,
+		int32(p.HP),             //nolint:gosec // Pokemon stats are small positive ints.
+		int32(p.Attack),         //nolint:gosec // Pokemon stats are small positive ints.
+		int32(p.Defense),        //nolint:gosec // Pokemon stats are small positive ints.
+		int32(p.SpecialAttack),  //nolint:gosec // Pokemon stats are small positive ints.
+		int32(p.SpecialDefense), //nolint:gosec // Pokemon stats are small positive ints.
+		int32(p.Speed),          //nolint:gosec // Pokemon stats are small positive ints.
+		int32(p.BaseExperience), //nolint:gosec // Base experience fits in int32.
+		int32(p.CaptureRate),    //nolint:gosec // Capture rate is 0-255.
+		p.IsLegendary,
+		p.IsMythical,
+		p.ContentHash(),
+		pgNullUUID(importID),
+	}
+}
+
+// stagePokemon copies the batch into a new staging table in one round trip.
+// A nil importID stages the rows without a last seen import.
+func stagePokemon(ctx context.Context, tx pgx.Tx, importID *uuid.UUID, pokemonBatch []pokemon.Pokemon) error {
+	_, err := tx.Exec(ctx, createPokemonStaging)
+	if err != nil {
+		return fmt.Errorf("create pokemon staging table: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"pokemon_staging"}, pokemonStagingColumns,
+		pgx.CopyFromSlice(len(pokemonBatch), func(i int) ([]any, error) {
+			return pokemonRow(pokemonBatch[i], importID), nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("copy pokemon into staging table: %w", err)
+	}
+
+	return nil
+}
+
+// mergeStagedPokemon upserts the staged batch with a single statement. It
+// returns whether each written row was inserted, keyed by Pokedex ID. Rows
+// left untouched because skipUnchanged is set are missing.
+func mergeStagedPokemon(ctx context.Context, tx pgx.Tx, skipUnchanged bool) (map[int]bool, error) {
+	rows, err := tx.Query(ctx, mergePokemonStaging, skipUnchanged)
+	if err != nil {
+		return nil, fmt.Errorf("merge staged pokemon: %w", err)
+	}
+
+	defer rows.Close()
+
+	written := make(map[int]bool)
+
+	for rows.Next() {
+		var (
+			pokedexID int32
+			inserted  bool
+		)
+
+		err = rows.Scan(&pokedexID, &inserted)
+		if err != nil {
+			return nil, fmt.Errorf("scan merged pokemon: %w", err)
+		}
+
+		written[int(pokedexID)] = inserted
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("merge staged pokemon: %w", err)
+	}
+
+	return written, nil
+}
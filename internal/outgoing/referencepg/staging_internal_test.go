@@ -0,0 +1,382 @@
+//go:build integration
+
+package referencepg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reference-service-go/internal/core/pokemon"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/monkescience/testastic"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// upsertPokemonRow upserts one Pokemon with the same conflict rules as the
+// staged merge.
+const upsertPokemonRow = `
+INSERT INTO pokemon (` + pokemonColumns + `)
+VALUES ($2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)` + upsertPokemonConflict
+
+// upsertFunc writes a batch of Pokemon seen by an import.
+type upsertFunc func(
+	ctx context.Context,
+	store *Store,
+	importID uuid.UUID,
+	pokemonBatch []pokemon.Pokemon,
+	skipUnchanged bool,
+) (pokemon.UpsertCounts, error)
+
+func upsertPokemonStaged(
+	ctx context.Context,
+	store *Store,
+	importID uuid.UUID,
+	pokemonBatch []pokemon.Pokemon,
+	skipUnchanged bool,
+) (pokemon.UpsertCounts, error) {
+	return store.UpsertPokemonBatch(ctx, importID, pokemonBatch, skipUnchanged)
+}
+
+// upsertPokemonPerRow upserts the batch with one statement per Pokemon, as
+// UpsertPokemonBatch did before it staged batches. It locks the stored rows
+// and records the history the same way.
+func upsertPokemonPerRow(
+	ctx context.Context,
+	store *Store,
+	importID uuid.UUID,
+	pokemonBatch []pokemon.Pokemon,
+	skipUnchanged bool,
+) (pokemon.UpsertCounts, error) {
+	var counts pokemon.UpsertCounts
+
+	tx, err := store.pool.Begin(ctx)
+	if err != nil {
+		return pokemon.UpsertCounts{}, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := store.queries.WithTx(tx)
+
+	ids := make([]int, 0, len(pokemonBatch))
+	for _, p := range pokemonBatch {
+		ids = append(ids, p.PokedexID)
+	}
+
+	rows, err := queries.LockPokemonByIDs(ctx, int32Slice(ids))
+	if err != nil {
+		return pokemon.UpsertCounts{}, fmt.Errorf("lock pokemon: %w", err)
+	}
+
+	stored := make(map[int]pokemon.Pokemon, len(rows))
+	for _, p := range toCorePokemonSlice(rows) {
+		stored[p.PokedexID] = p
+	}
+
+	for _, p := range pokemonBatch {
+		var (
+			pokedexID int32
+			inserted  bool
+		)
+
+		args := append([]any{skipUnchanged}, pokemonRow(p, &importID)...)
+		err = tx.QueryRow(ctx, upsertPokemonRow, args...).Scan(&pokedexID, &inserted)
+
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			counts.Unchanged++
+		case err != nil:
+			return pokemon.UpsertCounts{}, fmt.Errorf("upserting pokemon %d: %w", p.PokedexID, err)
+		case inserted:
+			counts.Inserted++
+		default:
+			counts.Updated++
+
+			change, changed, err := pokemonChange(importID, stored[p.PokedexID], p)
+			if err != nil {
+				return pokemon.UpsertCounts{}, err
+			}
+
+			if !changed {
+				continue
+			}
+
+			_, err = tx.Exec(ctx,
+				`INSERT INTO pokemon_history (pokedex_id, import_id, changes) VALUES ($1, $2, $3)`,
+				change.PokedexID, change.ImportID, change.Changes,
+			)
+			if err != nil {
+				return pokemon.UpsertCounts{}, fmt.Errorf("record change of pokemon %d: %w", p.PokedexID, err)
+			}
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return pokemon.UpsertCounts{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return counts, nil
+}
+
+// catalogRow is the part of a pokemon row an upsert decides on.
+type catalogRow struct {
+	PokedexID        int32
+	Name             string
+	HP               int32
+	ContentHash      string
+	LastSeenImportID pgtype.UUID
+	Deleted          bool
+}
+
+type historyRow struct {
+	PokedexID int32
+	ImportID  pgtype.UUID
+	Changes   string
+}
+
+func TestUpsertPokemonBatchMatchesPerRow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("requires Docker")
+	}
+
+	ctx := t.Context()
+	store := startTestStore(t)
+
+	seedImportID := createTestImport(t, store)
+	batchImportID := createTestImport(t, store)
+
+	// 1 is unchanged, 2 and 3 conflict with changed fields, 4 is unchanged
+	// but deactivated, and 5 is new.
+	seeded := benchmarkPokemon(4)
+	batch := benchmarkPokemon(5)
+	batch[1].HP = 60
+	batch[2].Name = "renamed"
+
+	tests := []struct {
+		name          string
+		skipUnchanged bool
+		wantCounts    pokemon.UpsertCounts
+		wantSeenBy    map[int32]uuid.UUID
+	}{
+		{
+			name:          "all rows",
+			skipUnchanged: false,
+			wantCounts:    pokemon.UpsertCounts{Inserted: 1, Updated: 4},
+			wantSeenBy: map[int32]uuid.UUID{
+				1: batchImportID, 2: batchImportID, 3: batchImportID, 4: batchImportID, 5: batchImportID,
+			},
+		},
+		{
+			name:          "skip unchanged",
+			skipUnchanged: true,
+			wantCounts:    pokemon.UpsertCounts{Inserted: 1, Updated: 3, Unchanged: 1},
+			wantSeenBy: map[int32]uuid.UUID{
+				1: seedImportID, 2: batchImportID, 3: batchImportID, 4: batchImportID, 5: batchImportID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := func(upsert upsertFunc) (pokemon.UpsertCounts, []catalogRow, []historyRow) {
+				// given: a catalog seeded by one import, with a deactivated species
+				_, err := store.pool.Exec(ctx, `TRUNCATE pokemon, pokemon_history CASCADE`)
+				testastic.NoError(t, err)
+
+				_, err = upsert(ctx, store, seedImportID, seeded, false)
+				testastic.NoError(t, err)
+
+				_, err = store.pool.Exec(ctx, `UPDATE pokemon SET deleted_at = NOW() WHERE pokedex_id = 4`)
+				testastic.NoError(t, err)
+
+				// when: the next import writes its batch
+				counts, err := upsert(ctx, store, batchImportID, batch, tt.skipUnchanged)
+				testastic.NoError(t, err)
+
+				catalog, history := snapshotCatalog(t, store)
+
+				return counts, catalog, history
+			}
+
+			stagedCounts, stagedCatalog, stagedHistory := run(upsertPokemonStaged)
+			perRowCounts, perRowCatalog, perRowHistory := run(upsertPokemonPerRow)
+
+			// then: the staged merge writes what upserting row by row writes
+			testastic.Equal(t, tt.wantCounts, stagedCounts)
+			testastic.Equal(t, perRowCounts, stagedCounts)
+			testastic.DeepEqual(t, perRowCatalog, stagedCatalog)
+			testastic.DeepEqual(t, perRowHistory, stagedHistory)
+
+			testastic.Len(t, stagedCatalog, 5)
+
+			for _, row := range stagedCatalog {
+				testastic.Equal(t, pgUUIDFromUUID(tt.wantSeenBy[row.PokedexID]), row.LastSeenImportID)
+				testastic.False(t, row.Deleted)
+			}
+
+			testastic.Len(t, stagedHistory, 2)
+			testastic.Equal(t, int32(2), stagedHistory[0].PokedexID)
+			testastic.Equal(t, int32(3), stagedHistory[1].PokedexID)
+
+			for _, row := range stagedHistory {
+				testastic.Equal(t, pgUUIDFromUUID(batchImportID), row.ImportID)
+			}
+		})
+	}
+}
+
+// BenchmarkUpsertPokemonBatch compares the staged COPY and merge of
+// UpsertPokemonBatch with upserting the batch one statement per Pokemon.
+//
+//	go test -tags integration -run '^$' -bench UpsertPokemonBatch ./internal/outgoing/referencepg
+func BenchmarkUpsertPokemonBatch(b *testing.B) {
+	if testing.Short() {
+		b.Skip("requires Docker")
+	}
+
+	ctx := context.Background()
+	store := startTestStore(b)
+	importID := createTestImport(b, store)
+
+	// A pipeline batch and a whole catalog in one batch.
+	for _, size := range []int{50, 1025} {
+		batch := benchmarkPokemon(size)
+
+		for _, run := range []struct {
+			name   string
+			upsert upsertFunc
+		}{
+			{name: "staged", upsert: upsertPokemonStaged},
+			{name: "per_row", upsert: upsertPokemonPerRow},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", run.name, size), func(b *testing.B) {
+				for b.Loop() {
+					_, err := run.upsert(ctx, store, importID, batch, false)
+					if err != nil {
+						b.Fatalf("upserting batch: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// snapshotCatalog returns the pokemon rows and their history in Pokedex order.
+func snapshotCatalog(t *testing.T, store *Store) ([]catalogRow, []historyRow) {
+	t.Helper()
+
+	rows, err := store.pool.Query(t.Context(), `
+SELECT pokedex_id, name, hp, content_hash, last_seen_import_id, deleted_at IS NOT NULL
+FROM pokemon
+ORDER BY pokedex_id`)
+	testastic.NoError(t, err)
+
+	catalog, err := pgx.CollectRows(rows, pgx.RowToStructByPos[catalogRow])
+	testastic.NoError(t, err)
+
+	rows, err = store.pool.Query(t.Context(), `
+SELECT pokedex_id, import_id, changes::text
+FROM pokemon_history
+ORDER BY pokedex_id, id`)
+	testastic.NoError(t, err)
+
+	history, err := pgx.CollectRows(rows, pgx.RowToStructByPos[historyRow])
+	testastic.NoError(t, err)
+
+	return catalog, history
+}
+
+func createTestImport(tb testing.TB, store *Store) uuid.UUID {
+	tb.Helper()
+
+	importID := uuid.Must(uuid.NewV7())
+
+	_, err := store.pool.Exec(context.Background(),
+		`INSERT INTO imports (id, source, status, item_count) VALUES ($1, 'pokeapi', 'processing', 0)`,
+		pgUUIDFromUUID(importID),
+	)
+	if err != nil {
+		tb.Fatalf("creating import: %v", err)
+	}
+
+	return importID
+}
+
+func startTestStore(tb testing.TB) *Store {
+	tb.Helper()
+
+	ctx := context.Background()
+
+	container, err := tcpostgres.Run(ctx,
+		"postgres:17-alpine",
+		tcpostgres.WithDatabase("pokemon_store"),
+		tcpostgres.WithUsername("test"),
+		tcpostgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second),
+		),
+	)
+	if err != nil {
+		tb.Fatalf("starting postgres container: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		termErr := container.Terminate(ctx)
+		if termErr != nil {
+			tb.Errorf("terminating postgres: %v", termErr)
+		}
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		tb.Fatalf("getting postgres connection string: %v", err)
+	}
+
+	err = Migrate(ctx, dsn)
+	if err != nil {
+		tb.Fatalf("running migrations: %v", err)
+	}
+
+	store, err := New(ctx, dsn)
+	if err != nil {
+		tb.Fatalf("connecting to postgres: %v", err)
+	}
+
+	tb.Cleanup(store.Close)
+
+	return store
+}
+
+// benchmarkPokemon returns size distinct Pokemon with Pokedex IDs from 1.
+func benchmarkPokemon(size int) []pokemon.Pokemon {
+	batch := make([]pokemon.Pokemon, 0, size)
+
+	for id := 1; id <= size; id++ {
+		batch = append(batch, pokemon.Pokemon{
+			PokedexID:      id,
+			Name:           fmt.Sprintf("pokemon-%d", id),
+			Rarity:         pokemon.RarityCommon,
+			Types:          []string{"normal"},
+			HP:             50,
+			Attack:         50,
+			Defense:        50,
+			SpecialAttack:  50,
+			SpecialDefense: 50,
+			Speed:          50,
+			BaseExperience: 64,
+			CaptureRate:    45,
+		})
+	}
+
+	return batch
+}
@@ -3,12 +3,15 @@
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reference-service-go/internal/core/catch"
 	"reference-service-go/internal/core/pokemon"
+	"reference-service-go/internal/outgoing/pokeapi"
 	"reference-service-go/internal/outgoing/referencepg/migrations"
 	"reference-service-go/internal/outgoing/referencepg/sqlcgen"
+	"time"
 
 	"github.com/exaring/otelpgx"
 	"github.com/google/uuid"
@@ -20,10 +23,19 @@
 )
 
 var (
-	_ pokemon.ImportStore       = (*Store)(nil)
-	_ pokemon.CatalogStore      = (*Store)(nil)
-	_ catch.RandomPokemonReader = (*Store)(nil)
-	_ catch.Store               = (*Store)(nil)
+	_ pokemon.ImportStore         = (*Store)(nil)
+	_ pokemon.ImportQueue         = (*Store)(nil)
+	_ pokemon.StaleImportStore    = (*Store)(nil)
+	_ pokemon.ImportErrorStore    = (*Store)(nil)
+	_ pokemon.ImportUploadStore   = (*Store)(nil)
+	_ pokemon.ImportDiffStore     = (*Store)(nil)
+	_ pokemon.ScheduleStore       = (*Store)(nil)
+	_ pokemon.CatalogStore        = (*Store)(nil)
+	_ pokemon.CatalogSeeder       = (*Store)(nil)
+	_ pokemon.PokemonHistoryStore = (*Store)(nil)
+	_ catch.RandomPokemonReader   = (*Store)(nil)
+	_ catch.Store                 = (*Store)(nil)
+	_ pokeapi.ResponseCache       = (*Store)(nil)
 )
 
 // Store is a PostgreSQL-backed adapter for Pokemon and catch operations.
@@ -66,86 +78,868 @@ func (s *Store) Close() {
 
 // CreateImport stores a new import job.
 func (s *Store) CreateImport(ctx context.Context, imp pokemon.Import) error {
-	err := s.queries.CreateImport(ctx, sqlcgen.CreateImportParams{
-		ID:        pgUUIDFromUUID(imp.ID),
-		Source:    imp.Source,
-		Status:    string(imp.Status),
-		ItemCount: int32(imp.ItemCount), //nolint:gosec // Import counts are bounded by species count.
-		CreatedAt: pgtype.Timestamptz{Time: imp.CreatedAt, Valid: true},
-		UpdatedAt: pgtype.Timestamptz{Time: imp.UpdatedAt, Valid: true},
+	created, err := s.queries.CreateImport(ctx, createImportParams(imp))
+	if err != nil {
+		return fmt.Errorf("create import: %w", err)
+	}
+
+	if created == 0 {
+		return pokemon.ErrImportInFlight
+	}
+
+	return nil
+}
+
+// GetImport returns an import by ID.
+func (s *Store) GetImport(ctx context.Context, id uuid.UUID) (pokemon.Import, error) {
+	row, err := s.queries.GetImport(ctx, pgUUIDFromUUID(id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pokemon.Import{}, pokemon.ErrImportNotFound
+		}
+
+		return pokemon.Import{}, fmt.Errorf("get import: %w", err)
+	}
+
+	return toCoreImport(row)
+}
+
+// GetActiveImport returns the pending, processing or interrupted import of a source.
+func (s *Store) GetActiveImport(ctx context.Context, source string) (pokemon.Import, error) {
+	row, err := s.queries.GetActiveImportBySource(ctx, source)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pokemon.Import{}, pokemon.ErrImportNotFound
+		}
+
+		return pokemon.Import{}, fmt.Errorf("get active import: %w", err)
+	}
+
+	return toCoreImport(row)
+}
+
+// CancelImport marks a pending, processing or interrupted import as cancelled.
+func (s *Store) CancelImport(ctx context.Context, id uuid.UUID) (pokemon.Import, error) {
+	row, err := s.queries.CancelImport(ctx, pgUUIDFromUUID(id))
+	if err == nil {
+		return toCoreImport(row)
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return pokemon.Import{}, fmt.Errorf("cancel import: %w", err)
+	}
+
+	_, err = s.GetImport(ctx, id)
+	if err != nil {
+		return pokemon.Import{}, err
+	}
+
+	return pokemon.Import{}, pokemon.ErrImportFinished
+}
+
+// ListImports returns imports matching the filter, newest first.
+func (s *Store) ListImports(ctx context.Context, params pokemon.ImportListParams) ([]pokemon.Import, error) {
+	filter := importFilterParams(params.Filter)
+
+	rows, err := s.queries.ListImports(ctx, sqlcgen.ListImportsParams{
+		Status:        filter.Status,
+		Source:        filter.Source,
+		CreatedAfter:  filter.CreatedAfter,
+		CreatedBefore: filter.CreatedBefore,
+		RowLimit:      int32(params.Limit),  //nolint:gosec // Pagination is validated at the API layer.
+		RowOffset:     int32(params.Offset), //nolint:gosec // Pagination is validated at the API layer.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list imports: %w", err)
+	}
+
+	imports := make([]pokemon.Import, 0, len(rows))
+
+	for _, row := range rows {
+		imp, err := toCoreImport(row)
+		if err != nil {
+			return nil, err
+		}
+
+		imports = append(imports, imp)
+	}
+
+	return imports, nil
+}
+
+// CountImports returns the number of imports matching the filter.
+func (s *Store) CountImports(ctx context.Context, filter pokemon.ImportFilter) (int64, error) {
+	count, err := s.queries.CountImports(ctx, importFilterParams(filter))
+	if err != nil {
+		return 0, fmt.Errorf("count imports: %w", err)
+	}
+
+	return count, nil
+}
+
+// RecordImportError stores a skipped species of a leased import and refreshes
+// the import's failed count.
+func (s *Store) RecordImportError(
+	ctx context.Context,
+	importID uuid.UUID,
+	owner string,
+	itemErr pokemon.ItemError,
+) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := s.queries.WithTx(tx)
+
+	var httpStatus pgtype.Int4
+	if itemErr.HTTPStatus != 0 {
+		httpStatus = pgtype.Int4{Int32: int32(itemErr.HTTPStatus), Valid: true} //nolint:gosec // HTTP statuses are 3 digits.
+	}
+
+	rows, err := queries.RecordImportError(ctx, sqlcgen.RecordImportErrorParams{
+		ImportID:   pgUUIDFromUUID(importID),
+		LeaseOwner: pgtype.Text{String: owner, Valid: true},
+		PokedexID:  int32(itemErr.PokedexID), //nolint:gosec // Pokedex IDs are small positive ints.
+		ErrorClass: string(itemErr.Class),
+		HttpStatus: httpStatus,
+		Message:    itemErr.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("record import error: %w", err)
+	}
+
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
+	}
+
+	err = queries.RefreshImportFailedCount(ctx, pgUUIDFromUUID(importID))
+	if err != nil {
+		return fmt.Errorf("refresh import failed count: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ClearImportErrors removes an import's errors for species after the given
+// Pokedex ID and refreshes its failed count.
+func (s *Store) ClearImportErrors(ctx context.Context, importID uuid.UUID, afterPokedexID int) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := s.queries.WithTx(tx)
+
+	err = queries.DeleteImportErrorsAfter(ctx, sqlcgen.DeleteImportErrorsAfterParams{
+		ImportID:       pgUUIDFromUUID(importID),
+		AfterPokedexID: int32(afterPokedexID), //nolint:gosec // Pokedex IDs are small positive ints.
+	})
+	if err != nil {
+		return fmt.Errorf("delete import errors: %w", err)
+	}
+
+	err = queries.RefreshImportFailedCount(ctx, pgUUIDFromUUID(importID))
+	if err != nil {
+		return fmt.Errorf("refresh import failed count: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListImportErrorIDs returns the Pokedex IDs an import failed on in ascending order.
+func (s *Store) ListImportErrorIDs(ctx context.Context, importID uuid.UUID) ([]int, error) {
+	rows, err := s.queries.ListImportErrorIDs(ctx, pgUUIDFromUUID(importID))
+	if err != nil {
+		return nil, fmt.Errorf("list import error ids: %w", err)
+	}
+
+	return intSlice(rows), nil
+}
+
+// ListImportErrors returns an import's skipped species ordered by Pokedex ID.
+func (s *Store) ListImportErrors(
+	ctx context.Context,
+	importID uuid.UUID,
+	limit, offset int,
+) ([]pokemon.ItemError, error) {
+	rows, err := s.queries.ListImportErrors(ctx, sqlcgen.ListImportErrorsParams{
+		ImportID: pgUUIDFromUUID(importID),
+		Limit:    int32(limit),  //nolint:gosec // Pagination is validated at the API layer.
+		Offset:   int32(offset), //nolint:gosec // Pagination is validated at the API layer.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list import errors: %w", err)
+	}
+
+	itemErrors := make([]pokemon.ItemError, 0, len(rows))
+	for _, row := range rows {
+		itemErrors = append(itemErrors, pokemon.ItemError{
+			PokedexID:  int(row.PokedexID),
+			Class:      pokemon.ItemErrorClass(row.ErrorClass),
+			HTTPStatus: int(row.HttpStatus.Int32),
+			Message:    row.Message,
+			CreatedAt:  row.CreatedAt.Time,
+		})
+	}
+
+	return itemErrors, nil
+}
+
+// RecordImportDiffs stores the diff of a dry-run import for each species in one transaction.
+func (s *Store) RecordImportDiffs(ctx context.Context, importID uuid.UUID, diffs []pokemon.SpeciesDiff) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := s.queries.WithTx(tx)
+
+	for _, diff := range diffs {
+		changes, err := json.Marshal(fieldChangesToJSON(diff.Changes))
+		if err != nil {
+			return fmt.Errorf("encode changes of pokemon %d: %w", diff.PokedexID, err)
+		}
+
+		err = queries.RecordImportDiff(ctx, sqlcgen.RecordImportDiffParams{
+			ImportID:  pgUUIDFromUUID(importID),
+			PokedexID: int32(diff.PokedexID), //nolint:gosec // Pokedex IDs are small positive ints.
+			Name:      diff.Name,
+			Kind:      string(diff.Kind),
+			Changes:   changes,
+		})
+		if err != nil {
+			return fmt.Errorf("record import diff of pokemon %d: %w", diff.PokedexID, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RecordRemovedSpecies records every catalog species the import neither has a
+// diff for nor failed to fetch as removed.
+func (s *Store) RecordRemovedSpecies(ctx context.Context, importID uuid.UUID) error {
+	err := s.queries.RecordRemovedImportDiffs(ctx, pgUUIDFromUUID(importID))
+	if err != nil {
+		return fmt.Errorf("record removed import diffs: %w", err)
+	}
+
+	return nil
+}
+
+// ListImportDiffs returns an import's diffs ordered by Pokedex ID. Without a
+// kind, unchanged species are left out.
+func (s *Store) ListImportDiffs(
+	ctx context.Context,
+	importID uuid.UUID,
+	kind *pokemon.DiffKind,
+	limit, offset int,
+) ([]pokemon.SpeciesDiff, error) {
+	var kindFilter pgtype.Text
+	if kind != nil {
+		kindFilter = pgtype.Text{String: string(*kind), Valid: true}
+	}
+
+	rows, err := s.queries.ListImportDiffs(ctx, sqlcgen.ListImportDiffsParams{
+		ImportID:  pgUUIDFromUUID(importID),
+		Kind:      kindFilter,
+		RowLimit:  int32(limit),  //nolint:gosec // Pagination is validated at the API layer.
+		RowOffset: int32(offset), //nolint:gosec // Pagination is validated at the API layer.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list import diffs: %w", err)
+	}
+
+	diffs := make([]pokemon.SpeciesDiff, 0, len(rows))
+
+	for _, row := range rows {
+		var changes []fieldChangeJSON
+
+		err = json.Unmarshal(row.Changes, &changes)
+		if err != nil {
+			return nil, fmt.Errorf("decode changes of pokemon %d: %w", row.PokedexID, err)
+		}
+
+		diffs = append(diffs, pokemon.SpeciesDiff{
+			PokedexID: int(row.PokedexID),
+			Name:      row.Name,
+			Kind:      pokemon.DiffKind(row.Kind),
+			Changes:   fieldChangesFromJSON(changes),
+		})
+	}
+
+	return diffs, nil
+}
+
+// SummarizeImportDiffs counts an import's diffs by kind.
+func (s *Store) SummarizeImportDiffs(ctx context.Context, importID uuid.UUID) (pokemon.DiffSummary, error) {
+	rows, err := s.queries.CountImportDiffsByKind(ctx, pgUUIDFromUUID(importID))
+	if err != nil {
+		return pokemon.DiffSummary{}, fmt.Errorf("count import diffs: %w", err)
+	}
+
+	var summary pokemon.DiffSummary
+
+	for _, row := range rows {
+		switch pokemon.DiffKind(row.Kind) {
+		case pokemon.DiffKindNew:
+			summary.New = int(row.Count)
+		case pokemon.DiffKindRemoved:
+			summary.Removed = int(row.Count)
+		case pokemon.DiffKindChanged:
+			summary.Changed = int(row.Count)
+		case pokemon.DiffKindUnchanged:
+			// Unchanged species are not part of the summary.
+		}
+	}
+
+	return summary, nil
+}
+
+// CreateImportWithUpload stores a new file import and its uploaded Pokemon in one transaction.
+func (s *Store) CreateImportWithUpload(ctx context.Context, imp pokemon.Import, upload []pokemon.Pokemon) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := s.queries.WithTx(tx)
+
+	created, err := queries.CreateImport(ctx, createImportParams(imp))
+	if err != nil {
+		return fmt.Errorf("create import: %w", err)
+	}
+
+	if created == 0 {
+		return pokemon.ErrImportInFlight
+	}
+
+	rows := make([]sqlcgen.CreateImportUploadsParams, 0, len(upload))
+	for _, p := range upload {
+		rows = append(rows, sqlcgen.CreateImportUploadsParams{
+			ImportID:       pgUUIDFromUUID(imp.ID),
+			PokedexID:      int32(p.PokedexID), //nolint:gosec // Uploads are validated before they are stored.
+			Name:           p.Name,
+			Rarity:         string(p.Rarity),
+			Types:          p.Types,
+			SpriteUrl:      p.SpriteURL,
+			Hp:             int32(p.HP),             //nolint:gosec // Uploads are validated before they are stored.
+			Attack:         int32(p.Attack),         //nolint:gosec // Uploads are validated before they are stored.
+			Defense:        int32(p.Defense),        //nolint:gosec // Uploads are validated before they are stored.
+			SpecialAttack:  int32(p.SpecialAttack),  //nolint:gosec // Uploads are validated before they are stored.
+			SpecialDefense: int32(p.SpecialDefense), //nolint:gosec // Uploads are validated before they are stored.
+			Speed:          int32(p.Speed),          //nolint:gosec // Uploads are validated before they are stored.
+			BaseExperience: int32(p.BaseExperience), //nolint:gosec // Uploads are validated before they are stored.
+			CaptureRate:    int32(p.CaptureRate),    //nolint:gosec // Capture rate is 0-255.
+			IsLegendary:    p.IsLegendary,
+			IsMythical:     p.IsMythical,
+		})
+	}
+
+	_, err = queries.CreateImportUploads(ctx, rows)
+	if err != nil {
+		return fmt.Errorf("create import uploads: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetUploadedPokemon returns an uploaded Pokemon of a file import.
+func (s *Store) GetUploadedPokemon(ctx context.Context, importID uuid.UUID, pokedexID int) (pokemon.Pokemon, error) {
+	row, err := s.queries.GetUploadedPokemon(ctx, sqlcgen.GetUploadedPokemonParams{
+		ImportID:  pgUUIDFromUUID(importID),
+		PokedexID: int32(pokedexID), //nolint:gosec // Import targets are validated Pokedex IDs.
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pokemon.Pokemon{}, pokemon.ErrPokemonNotFound
+		}
+
+		return pokemon.Pokemon{}, fmt.Errorf("get uploaded pokemon: %w", err)
+	}
+
+	return toCorePokemonFromUpload(row), nil
+}
+
+// ListUploadedPokemon returns the uploaded Pokemon of a file import with the given Pokedex IDs.
+func (s *Store) ListUploadedPokemon(
+	ctx context.Context,
+	importID uuid.UUID,
+	pokedexIDs []int,
+) ([]pokemon.Pokemon, error) {
+	rows, err := s.queries.ListUploadedPokemon(ctx, sqlcgen.ListUploadedPokemonParams{
+		ImportID:   pgUUIDFromUUID(importID),
+		PokedexIds: int32Slice(pokedexIDs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list uploaded pokemon: %w", err)
+	}
+
+	result := make([]pokemon.Pokemon, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, toCorePokemonFromUpload(row))
+	}
+
+	return result, nil
+}
+
+// FireSchedule calls fire for a tick of the named schedule in a transaction
+// holding the schedule's advisory lock. The tick is recorded in the same
+// transaction, and fire gets the imports of that transaction, so the import it
+// creates is committed along with the tick. A replica that fails to fire
+// leaves both to the others.
+func (s *Store) FireSchedule(
+	ctx context.Context,
+	name string,
+	tick time.Time,
+	fire func(ctx context.Context, imports pokemon.ImportStore) error,
+) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := s.queries.WithTx(tx)
+
+	locked, err := queries.TryLockImportSchedule(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("lock import schedule: %w", err)
+	}
+
+	if !locked {
+		return false, nil
+	}
+
+	_, err = queries.ClaimImportScheduleTick(ctx, sqlcgen.ClaimImportScheduleTickParams{
+		Name: name,
+		Tick: pgtype.Timestamptz{Time: tick, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("claim import schedule tick: %w", err)
+	}
+
+	// Without a pool the store can only run queries on the transaction.
+	err = fire(ctx, &Store{queries: queries})
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// ClaimImport leases the oldest pending or interrupted import, or a processing
+// import whose lease has expired and that was resumed fewer than maxResumes
+// times, to the given owner.
+func (s *Store) ClaimImport(
+	ctx context.Context,
+	owner string,
+	lease time.Duration,
+	maxResumes int,
+) (pokemon.Import, error) {
+	row, err := s.queries.ClaimImport(ctx, sqlcgen.ClaimImportParams{
+		LeaseOwner:   pgtype.Text{String: owner, Valid: true},
+		LeaseSeconds: lease.Seconds(),
+		MaxResumes:   int32(maxResumes), //nolint:gosec // Max resumes is a small config value.
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pokemon.Import{}, pokemon.ErrNoImportQueued
+		}
+
+		return pokemon.Import{}, fmt.Errorf("claim import: %w", err)
+	}
+
+	return toCoreImport(row)
+}
+
+// RenewImportLease extends the lease the owner holds on a processing import.
+func (s *Store) RenewImportLease(ctx context.Context, id uuid.UUID, owner string, lease time.Duration) error {
+	rows, err := s.queries.RenewImportLease(ctx, sqlcgen.RenewImportLeaseParams{
+		ID:           pgUUIDFromUUID(id),
+		LeaseOwner:   pgtype.Text{String: owner, Valid: true},
+		LeaseSeconds: lease.Seconds(),
+	})
+	if err != nil {
+		return fmt.Errorf("renew import lease: %w", err)
+	}
+
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
+	}
+
+	return nil
+}
+
+// UpdateImportProgress records the item count and checkpoint of a leased import.
+func (s *Store) UpdateImportProgress(
+	ctx context.Context,
+	id uuid.UUID,
+	owner string,
+	progress pokemon.ImportProgress,
+) error {
+	rows, err := s.queries.UpdateImportProgress(ctx, sqlcgen.UpdateImportProgressParams{
+		ID:                  pgUUIDFromUUID(id),
+		LeaseOwner:          pgtype.Text{String: owner, Valid: true},
+		ItemCount:           int32(progress.ItemCount),         //nolint:gosec // Import counts are bounded by species count.
+		CheckpointPokedexID: int32(progress.Checkpoint),        //nolint:gosec // Pokedex IDs are small positive ints.
+		InsertedCount:       int32(progress.Upserts.Inserted),  //nolint:gosec // Import counts are bounded by species count.
+		UpdatedCount:        int32(progress.Upserts.Updated),   //nolint:gosec // Import counts are bounded by species count.
+		UnchangedCount:      int32(progress.Upserts.Unchanged), //nolint:gosec // Import counts are bounded by species count.
+	})
+	if err != nil {
+		return fmt.Errorf("update import progress: %w", err)
+	}
+
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
+	}
+
+	return nil
+}
+
+// UpdateImportExpectedCount records how many species a leased import targets.
+func (s *Store) UpdateImportExpectedCount(ctx context.Context, id uuid.UUID, owner string, expected int) error {
+	rows, err := s.queries.UpdateImportExpectedCount(ctx, sqlcgen.UpdateImportExpectedCountParams{
+		ID:            pgUUIDFromUUID(id),
+		LeaseOwner:    pgtype.Text{String: owner, Valid: true},
+		ExpectedCount: int32(expected), //nolint:gosec // Import counts are bounded by species count.
 	})
 	if err != nil {
-		return fmt.Errorf("create import: %w", err)
+		return fmt.Errorf("update import expected count: %w", err)
+	}
+
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
 	}
 
 	return nil
 }
 
-// GetImport returns an import by ID.
-func (s *Store) GetImport(ctx context.Context, id uuid.UUID) (pokemon.Import, error) {
-	row, err := s.queries.GetImport(ctx, pgUUIDFromUUID(id))
+// FinishImport moves a leased import to a terminal status and releases the lease.
+func (s *Store) FinishImport(ctx context.Context, id uuid.UUID, owner string, status pokemon.ImportStatus) error {
+	rows, err := s.queries.FinishImport(ctx, sqlcgen.FinishImportParams{
+		ID:         pgUUIDFromUUID(id),
+		LeaseOwner: pgtype.Text{String: owner, Valid: true},
+		Status:     string(status),
+	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return pokemon.Import{}, pokemon.ErrImportNotFound
-		}
+		return fmt.Errorf("finish import: %w", err)
+	}
 
-		return pokemon.Import{}, fmt.Errorf("get import: %w", err)
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
 	}
 
-	return toCoreImport(row)
+	return nil
 }
 
-// UpdateImportStatus updates the status and item count for an import.
-func (s *Store) UpdateImportStatus(
+// FailImport marks a leased import failed in the given phase with the message.
+// The failure keeps the import's persisted count.
+func (s *Store) FailImport(
 	ctx context.Context,
 	id uuid.UUID,
-	status pokemon.ImportStatus,
-	itemCount int,
+	owner string,
+	phase pokemon.ImportPhase,
+	message string,
 ) error {
-	err := s.queries.UpdateImportStatus(ctx, sqlcgen.UpdateImportStatusParams{
-		ID:        pgUUIDFromUUID(id),
-		Status:    string(status),
-		ItemCount: int32(itemCount), //nolint:gosec // Import counts are bounded by species count.
+	rows, err := s.queries.FailImport(ctx, sqlcgen.FailImportParams{
+		ID:             pgUUIDFromUUID(id),
+		LeaseOwner:     pgtype.Text{String: owner, Valid: true},
+		FailurePhase:   pgtype.Text{String: string(phase), Valid: true},
+		FailureMessage: pgtype.Text{String: message, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("fail import: %w", err)
+	}
+
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
+	}
+
+	return nil
+}
+
+// ListStaleImports returns the processing imports whose lease expired and that
+// were not updated since updatedBefore, oldest first.
+func (s *Store) ListStaleImports(ctx context.Context, updatedBefore time.Time) ([]pokemon.Import, error) {
+	rows, err := s.queries.ListStaleImports(ctx, pgtype.Timestamptz{Time: updatedBefore, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list stale imports: %w", err)
+	}
+
+	imports := make([]pokemon.Import, 0, len(rows))
+
+	for _, row := range rows {
+		imp, err := toCoreImport(row)
+		if err != nil {
+			return nil, err
+		}
+
+		imports = append(imports, imp)
+	}
+
+	return imports, nil
+}
+
+// ResumeStaleImport marks a stale import interrupted, so the next worker
+// resumes it. It reports false when the import changed since it was listed.
+func (s *Store) ResumeStaleImport(ctx context.Context, imp pokemon.Import) (bool, error) {
+	rows, err := s.queries.ResumeStaleImport(ctx, sqlcgen.ResumeStaleImportParams{
+		ID:        pgUUIDFromUUID(imp.ID),
+		UpdatedAt: pgtype.Timestamptz{Time: imp.UpdatedAt, Valid: true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("resume stale import: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// FailStaleImport marks a stale import failed in the recovery phase with the
+// message. It reports false when the import changed since it was listed.
+func (s *Store) FailStaleImport(ctx context.Context, imp pokemon.Import, message string) (bool, error) {
+	rows, err := s.queries.FailStaleImport(ctx, sqlcgen.FailStaleImportParams{
+		ID:             pgUUIDFromUUID(imp.ID),
+		UpdatedAt:      pgtype.Timestamptz{Time: imp.UpdatedAt, Valid: true},
+		FailureMessage: pgtype.Text{String: message, Valid: true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("fail stale import: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// InterruptImport releases the lease the owner holds on a processing import and
+// marks it interrupted, so the next worker polling the queue resumes it.
+func (s *Store) InterruptImport(ctx context.Context, id uuid.UUID, owner string) error {
+	rows, err := s.queries.InterruptImport(ctx, sqlcgen.InterruptImportParams{
+		ID:         pgUUIDFromUUID(id),
+		LeaseOwner: pgtype.Text{String: owner, Valid: true},
 	})
 	if err != nil {
-		return fmt.Errorf("update import status: %w", err)
+		return fmt.Errorf("interrupt import: %w", err)
+	}
+
+	if rows == 0 {
+		return pokemon.ErrImportLeaseLost
 	}
 
 	return nil
 }
 
 // UpsertPokemonBatch inserts or updates a batch of Pokemon in one transaction.
-func (s *Store) UpsertPokemonBatch(ctx context.Context, pokemonBatch []pokemon.Pokemon) error {
+// The batch is copied into a staging table and merged with a single statement,
+// so a batch takes a few round trips however large it is. With skipUnchanged,
+// rows whose content hash matches are not written. Written rows are marked as
+// seen by the import, and the fields changed on existing rows are recorded in
+// the pokemon history.
+func (s *Store) UpsertPokemonBatch(
+	ctx context.Context,
+	importID uuid.UUID,
+	pokemonBatch []pokemon.Pokemon,
+	skipUnchanged bool,
+) (pokemon.UpsertCounts, error) {
+	var counts pokemon.UpsertCounts
+
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("begin transaction: %w", err)
+		return pokemon.UpsertCounts{}, fmt.Errorf("begin transaction: %w", err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
 
 	queries := s.queries.WithTx(tx)
 
+	ids := make([]int, 0, len(pokemonBatch))
 	for _, p := range pokemonBatch {
-		err = queries.UpsertPokemon(ctx, sqlcgen.UpsertPokemonParams{
-			PokedexID:      int32(p.PokedexID), //nolint:gosec // Pokedex IDs are small positive ints.
-			Name:           p.Name,
-			Rarity:         string(p.Rarity),
-			Types:          p.Types,
-			SpriteUrl:      p.SpriteURL,
-			Hp:             int32(p.HP),             //nolint:gosec // Pokemon stats are small positive ints.
-			Attack:         int32(p.Attack),         //nolint:gosec // Pokemon stats are small positive ints.
-			Defense:        int32(p.Defense),        //nolint:gosec // Pokemon stats are small positive ints.
-			SpecialAttack:  int32(p.SpecialAttack),  //nolint:gosec // Pokemon stats are small positive ints.
-			SpecialDefense: int32(p.SpecialDefense), //nolint:gosec // Pokemon stats are small positive ints.
-			Speed:          int32(p.Speed),          //nolint:gosec // Pokemon stats are small positive ints.
-			BaseExperience: int32(p.BaseExperience), //nolint:gosec // Base experience fits in int32.
-			CaptureRate:    int32(p.CaptureRate),    //nolint:gosec // Capture rate is 0-255.
-			IsLegendary:    p.IsLegendary,
-			IsMythical:     p.IsMythical,
-		})
+		ids = append(ids, p.PokedexID)
+	}
+
+	// The stored rows stay locked until commit, so the history records the
+	// values the batch actually overwrote.
+	rows, err := queries.LockPokemonByIDs(ctx, int32Slice(ids))
+	if err != nil {
+		return pokemon.UpsertCounts{}, fmt.Errorf("lock pokemon: %w", err)
+	}
+
+	stored := make(map[int]pokemon.Pokemon, len(rows))
+	for _, p := range toCorePokemonSlice(rows) {
+		stored[p.PokedexID] = p
+	}
+
+	err = stagePokemon(ctx, tx, &importID, pokemonBatch)
+	if err != nil {
+		return pokemon.UpsertCounts{}, err
+	}
+
+	written, err := mergeStagedPokemon(ctx, tx, skipUnchanged)
+	if err != nil {
+		return pokemon.UpsertCounts{}, err
+	}
+
+	var history []sqlcgen.RecordPokemonChangesParams
+
+	for _, p := range pokemonBatch {
+		inserted, ok := written[p.PokedexID]
+
+		switch {
+		case !ok:
+			counts.Unchanged++
+		case inserted:
+			counts.Inserted++
+		default:
+			counts.Updated++
+
+			change, changed, err := pokemonChange(importID, stored[p.PokedexID], p)
+			if err != nil {
+				return pokemon.UpsertCounts{}, err
+			}
+
+			if changed {
+				history = append(history, change)
+			}
+		}
+	}
+
+	if len(history) > 0 {
+		_, err = queries.RecordPokemonChanges(ctx, history)
+		if err != nil {
+			return pokemon.UpsertCounts{}, fmt.Errorf("record pokemon changes: %w", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return pokemon.UpsertCounts{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ListPokemonHistory returns the changes imports made to a species, newest
+// first.
+func (s *Store) ListPokemonHistory(ctx context.Context, pokedexID, limit, offset int) ([]pokemon.PokemonChange, error) {
+	rows, err := s.queries.ListPokemonHistory(ctx, sqlcgen.ListPokemonHistoryParams{
+		PokedexID: int32(pokedexID), //nolint:gosec // API validates Pokedex IDs before calling the store.
+		Limit:     int32(limit),     //nolint:gosec // Pagination is validated at the API layer.
+		Offset:    int32(offset),    //nolint:gosec // Pagination is validated at the API layer.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list pokemon history: %w", err)
+	}
+
+	history := make([]pokemon.PokemonChange, 0, len(rows))
+
+	for _, row := range rows {
+		importID, err := uuidFromPG(row.ImportID)
+		if err != nil {
+			return nil, fmt.Errorf("convert import id: %w", err)
+		}
+
+		var changes []fieldChangeJSON
+
+		err = json.Unmarshal(row.Changes, &changes)
 		if err != nil {
-			return fmt.Errorf("upserting pokemon %d: %w", p.PokedexID, err)
+			return nil, fmt.Errorf("decode changes of pokemon %d: %w", row.PokedexID, err)
 		}
+
+		history = append(history, pokemon.PokemonChange{
+			PokedexID: int(row.PokedexID),
+			ImportID:  importID,
+			Changes:   fieldChangesFromJSON(changes),
+			ChangedAt: row.ChangedAt.Time,
+		})
+	}
+
+	return history, nil
+}
+
+// CountPokemonHistory returns the number of changes imports made to a species.
+func (s *Store) CountPokemonHistory(ctx context.Context, pokedexID int) (int64, error) {
+	//nolint:gosec // API validates Pokedex IDs before calling the store.
+	count, err := s.queries.CountPokemonHistory(ctx, int32(pokedexID))
+	if err != nil {
+		return 0, fmt.Errorf("count pokemon history: %w", err)
+	}
+
+	return count, nil
+}
+
+// SeedCatalog stores the Pokemon if the catalog is empty. The pokemon table
+// stays locked against concurrent writes until the seed is committed, so
+// replicas seeding at once cannot both see an empty catalog.
+func (s *Store) SeedCatalog(ctx context.Context, pokemonBatch []pokemon.Pokemon) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // Rollback is a no-op after commit.
+
+	queries := s.queries.WithTx(tx)
+
+	err = queries.LockPokemonForSeeding(ctx)
+	if err != nil {
+		return fmt.Errorf("lock pokemon: %w", err)
+	}
+
+	count, err := queries.CountPokemon(ctx, true)
+	if err != nil {
+		return fmt.Errorf("count pokemon: %w", err)
+	}
+
+	if count > 0 {
+		return pokemon.ErrCatalogNotEmpty
+	}
+
+	err = stagePokemon(ctx, tx, nil, pokemonBatch)
+	if err != nil {
+		return err
+	}
+
+	_, err = mergeStagedPokemon(ctx, tx, false)
+	if err != nil {
+		return err
 	}
 
 	err = tx.Commit(ctx)
@@ -156,7 +950,18 @@ func (s *Store) UpsertPokemonBatch(ctx context.Context, pokemonBatch []pokemon.P
 	return nil
 }
 
-// GetPokemonByID returns a Pokemon by Pokedex ID.
+// DeactivateUnseenPokemon marks the active species the import neither wrote nor
+// recorded an error for as inactive.
+func (s *Store) DeactivateUnseenPokemon(ctx context.Context, importID uuid.UUID) (int64, error) {
+	rows, err := s.queries.DeactivateUnseenPokemon(ctx, pgUUIDFromUUID(importID))
+	if err != nil {
+		return 0, fmt.Errorf("deactivate unseen pokemon: %w", err)
+	}
+
+	return rows, nil
+}
+
+// GetPokemonByID returns a Pokemon by Pokedex ID, even an inactive one.
 func (s *Store) GetPokemonByID(ctx context.Context, pokedexID int) (pokemon.Pokemon, error) {
 	//nolint:gosec // API validates Pokedex IDs before calling the store.
 	row, err := s.queries.GetPokemonByID(ctx, int32(pokedexID))
@@ -171,13 +976,24 @@ func (s *Store) GetPokemonByID(ctx context.Context, pokedexID int) (pokemon.Poke
 	return toCorePokemon(row), nil
 }
 
+// ListPokemonByIDs returns the stored Pokemon with the given Pokedex IDs.
+func (s *Store) ListPokemonByIDs(ctx context.Context, pokedexIDs []int) ([]pokemon.Pokemon, error) {
+	rows, err := s.queries.ListPokemonByIDs(ctx, int32Slice(pokedexIDs))
+	if err != nil {
+		return nil, fmt.Errorf("list pokemon by ids: %w", err)
+	}
+
+	return toCorePokemonSlice(rows), nil
+}
+
 // ListPokemon returns Pokemon using optional rarity filtering.
 func (s *Store) ListPokemon(ctx context.Context, params pokemon.ListParams) ([]pokemon.Pokemon, error) {
 	if params.Rarity != nil {
 		rows, err := s.queries.ListPokemonByRarity(ctx, sqlcgen.ListPokemonByRarityParams{
-			Rarity: string(*params.Rarity),
-			Limit:  int32(params.Limit),  //nolint:gosec // Pagination is validated at the API layer.
-			Offset: int32(params.Offset), //nolint:gosec // Pagination is validated at the API layer.
+			Rarity:          string(*params.Rarity),
+			IncludeInactive: params.IncludeInactive,
+			RowLimit:        int32(params.Limit),  //nolint:gosec // Pagination is validated at the API layer.
+			RowOffset:       int32(params.Offset), //nolint:gosec // Pagination is validated at the API layer.
 		})
 		if err != nil {
 			return nil, fmt.Errorf("list pokemon by rarity: %w", err)
@@ -187,8 +1003,9 @@ func (s *Store) ListPokemon(ctx context.Context, params pokemon.ListParams) ([]p
 	}
 
 	rows, err := s.queries.ListPokemon(ctx, sqlcgen.ListPokemonParams{
-		Limit:  int32(params.Limit),  //nolint:gosec // Pagination is validated at the API layer.
-		Offset: int32(params.Offset), //nolint:gosec // Pagination is validated at the API layer.
+		IncludeInactive: params.IncludeInactive,
+		RowLimit:        int32(params.Limit),  //nolint:gosec // Pagination is validated at the API layer.
+		RowOffset:       int32(params.Offset), //nolint:gosec // Pagination is validated at the API layer.
 	})
 	if err != nil {
 		return nil, fmt.Errorf("list pokemon: %w", err)
@@ -198,9 +1015,12 @@ func (s *Store) ListPokemon(ctx context.Context, params pokemon.ListParams) ([]p
 }
 
 // CountPokemon returns the total count for the given optional rarity filter.
-func (s *Store) CountPokemon(ctx context.Context, rarity *pokemon.Rarity) (int64, error) {
+func (s *Store) CountPokemon(ctx context.Context, rarity *pokemon.Rarity, includeInactive bool) (int64, error) {
 	if rarity != nil {
-		count, err := s.queries.CountPokemonByRarity(ctx, string(*rarity))
+		count, err := s.queries.CountPokemonByRarity(ctx, sqlcgen.CountPokemonByRarityParams{
+			Rarity:          string(*rarity),
+			IncludeInactive: includeInactive,
+		})
 		if err != nil {
 			return 0, fmt.Errorf("count pokemon by rarity: %w", err)
 		}
@@ -208,7 +1028,7 @@ func (s *Store) CountPokemon(ctx context.Context, rarity *pokemon.Rarity) (int64
 		return count, nil
 	}
 
-	count, err := s.queries.CountPokemon(ctx)
+	count, err := s.queries.CountPokemon(ctx, includeInactive)
 	if err != nil {
 		return 0, fmt.Errorf("count pokemon: %w", err)
 	}
@@ -216,7 +1036,7 @@ func (s *Store) CountPokemon(ctx context.Context, rarity *pokemon.Rarity) (int64
 	return count, nil
 }
 
-// GetRandomPokemonByRarity returns a random Pokemon for the given rarity.
+// GetRandomPokemonByRarity returns a random active Pokemon for the given rarity.
 func (s *Store) GetRandomPokemonByRarity(ctx context.Context, rarity pokemon.Rarity) (pokemon.Pokemon, error) {
 	row, err := s.queries.GetRandomPokemonByRarity(ctx, string(rarity))
 	if err != nil {
@@ -271,6 +1091,69 @@ func (s *Store) GetCatch(ctx context.Context, id uuid.UUID) (catch.Catch, error)
 	}, nil
 }
 
+// GetResponse returns the cached PokeAPI response for a URL.
+func (s *Store) GetResponse(ctx context.Context, url string) (pokeapi.CachedResponse, error) {
+	row, err := s.queries.GetPokeAPIResponse(ctx, url)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pokeapi.CachedResponse{}, pokeapi.ErrCacheMiss
+		}
+
+		return pokeapi.CachedResponse{}, fmt.Errorf("get pokeapi response: %w", err)
+	}
+
+	return pokeapi.CachedResponse{
+		URL:          row.Url,
+		ETag:         row.Etag,
+		LastModified: row.LastModified,
+		ContentType:  row.ContentType,
+		Body:         row.Body,
+		ValidatedAt:  row.ValidatedAt.Time,
+	}, nil
+}
+
+// PutResponse caches a PokeAPI response, replacing the one cached for its URL.
+func (s *Store) PutResponse(ctx context.Context, resp pokeapi.CachedResponse) error {
+	err := s.queries.PutPokeAPIResponse(ctx, sqlcgen.PutPokeAPIResponseParams{
+		Url:          resp.URL,
+		Etag:         resp.ETag,
+		LastModified: resp.LastModified,
+		ContentType:  resp.ContentType,
+		Body:         resp.Body,
+		ValidatedAt:  pgtype.Timestamptz{Time: resp.ValidatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("put pokeapi response: %w", err)
+	}
+
+	return nil
+}
+
+// MarkResponseValidated records that the cached PokeAPI response for a URL was
+// revalidated.
+func (s *Store) MarkResponseValidated(ctx context.Context, url string, at time.Time) error {
+	err := s.queries.MarkPokeAPIResponseValidated(ctx, sqlcgen.MarkPokeAPIResponseValidatedParams{
+		Url:         url,
+		ValidatedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("mark pokeapi response validated: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteResponsesValidatedBefore evicts the cached PokeAPI responses last
+// validated before the given time.
+func (s *Store) DeleteResponsesValidatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	evicted, err := s.queries.DeletePokeAPIResponsesValidatedBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("delete pokeapi responses: %w", err)
+	}
+
+	return evicted, nil
+}
+
 // Migrate runs all pending goose migrations against the given DSN.
 func Migrate(ctx context.Context, dsn string) error {
 	db, err := sql.Open("pgx", dsn)
@@ -297,10 +1180,84 @@ func Migrate(ctx context.Context, dsn string) error {
 
 var errNullUUID = errors.New("uuid is null")
 
+func createImportParams(imp pokemon.Import) sqlcgen.CreateImportParams {
+	return sqlcgen.CreateImportParams{
+		ID:             pgUUIDFromUUID(imp.ID),
+		Source:         imp.Source,
+		Status:         string(imp.Status),
+		ItemCount:      int32(imp.ItemCount), //nolint:gosec // Import counts are bounded by species count.
+		CreatedAt:      pgtype.Timestamptz{Time: imp.CreatedAt, Valid: true},
+		UpdatedAt:      pgtype.Timestamptz{Time: imp.UpdatedAt, Valid: true},
+		ParentImportID: pgNullUUID(imp.ParentID),
+		PokedexIds:     int32Slice(imp.Targets.PokedexIDs),
+		FromPokedexID:  pgNullInt4(imp.Targets.From),
+		ToPokedexID:    pgNullInt4(imp.Targets.To),
+		Mode:           string(imp.Mode),
+	}
+}
+
+// pokemonChange builds the history entry of the import overwriting stored
+// with p. It reports false if no recorded field changed.
+func pokemonChange(
+	importID uuid.UUID,
+	stored, p pokemon.Pokemon,
+) (sqlcgen.RecordPokemonChangesParams, bool, error) {
+	changes := stored.Diff(p)
+	if len(changes) == 0 {
+		return sqlcgen.RecordPokemonChangesParams{}, false, nil
+	}
+
+	encoded, err := json.Marshal(fieldChangesToJSON(changes))
+	if err != nil {
+		return sqlcgen.RecordPokemonChangesParams{}, false, fmt.Errorf(
+			"encode changes of pokemon %d: %w", p.PokedexID, err)
+	}
+
+	return sqlcgen.RecordPokemonChangesParams{
+		PokedexID: int32(p.PokedexID), //nolint:gosec // Pokedex IDs are small positive ints.
+		ImportID:  pgUUIDFromUUID(importID),
+		Changes:   encoded,
+	}, true, nil
+}
+
 func pgUUIDFromUUID(id uuid.UUID) pgtype.UUID {
 	return pgtype.UUID{Bytes: [16]byte(id), Valid: true}
 }
 
+func pgNullUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+
+	return pgUUIDFromUUID(*id)
+}
+
+func pgNullInt4(value int) pgtype.Int4 {
+	if value == 0 {
+		return pgtype.Int4{}
+	}
+
+	return pgtype.Int4{Int32: int32(value), Valid: true} //nolint:gosec // Pokedex IDs are small positive ints.
+}
+
+func int32Slice(values []int) []int32 {
+	out := make([]int32, 0, len(values))
+	for _, value := range values {
+		out = append(out, int32(value)) //nolint:gosec // Pokedex IDs are small positive ints.
+	}
+
+	return out
+}
+
+func intSlice(values []int32) []int {
+	out := make([]int, 0, len(values))
+	for _, value := range values {
+		out = append(out, int(value))
+	}
+
+	return out
+}
+
 func uuidFromPG(id pgtype.UUID) (uuid.UUID, error) {
 	if !id.Valid {
 		return uuid.Nil, errNullUUID
@@ -309,19 +1266,110 @@ func uuidFromPG(id pgtype.UUID) (uuid.UUID, error) {
 	return uuid.UUID(id.Bytes), nil
 }
 
+func timeFromPG(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+
+	return &ts.Time
+}
+
+func importFilterParams(filter pokemon.ImportFilter) sqlcgen.CountImportsParams {
+	var params sqlcgen.CountImportsParams
+
+	if filter.Status != nil {
+		params.Status = pgtype.Text{String: string(*filter.Status), Valid: true}
+	}
+
+	if filter.Source != nil {
+		params.Source = pgtype.Text{String: *filter.Source, Valid: true}
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		params.CreatedAfter = pgtype.Timestamptz{Time: filter.CreatedAfter, Valid: true}
+	}
+
+	if !filter.CreatedBefore.IsZero() {
+		params.CreatedBefore = pgtype.Timestamptz{Time: filter.CreatedBefore, Valid: true}
+	}
+
+	return params
+}
+
+// fieldChangeJSON is the stored form of a pokemon.FieldChange.
+type fieldChangeJSON struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+func fieldChangesToJSON(changes []pokemon.FieldChange) []fieldChangeJSON {
+	out := make([]fieldChangeJSON, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, fieldChangeJSON(change))
+	}
+
+	return out
+}
+
+func fieldChangesFromJSON(changes []fieldChangeJSON) []pokemon.FieldChange {
+	out := make([]pokemon.FieldChange, 0, len(changes))
+	for _, change := range changes {
+		out = append(out, pokemon.FieldChange(change))
+	}
+
+	return out
+}
+
 func toCoreImport(row sqlcgen.Import) (pokemon.Import, error) {
 	id, err := uuidFromPG(row.ID)
 	if err != nil {
 		return pokemon.Import{}, fmt.Errorf("convert import id: %w", err)
 	}
 
+	var parentID *uuid.UUID
+
+	if row.ParentImportID.Valid {
+		parent := uuid.UUID(row.ParentImportID.Bytes)
+		parentID = &parent
+	}
+
+	var failure *pokemon.ImportFailure
+
+	if row.FailurePhase.Valid {
+		failure = &pokemon.ImportFailure{
+			Phase:          pokemon.ImportPhase(row.FailurePhase.String),
+			Message:        row.FailureMessage.String,
+			PersistedCount: int(row.FailurePersistedCount.Int32),
+		}
+	}
+
 	return pokemon.Import{
 		ID:        id,
 		Source:    row.Source,
 		Status:    pokemon.ImportStatus(row.Status),
+		Mode:      pokemon.ImportMode(row.Mode),
 		ItemCount: int(row.ItemCount),
-		CreatedAt: row.CreatedAt.Time,
-		UpdatedAt: row.UpdatedAt.Time,
+		Upserts: pokemon.UpsertCounts{
+			Inserted:  int(row.InsertedCount),
+			Updated:   int(row.UpdatedCount),
+			Unchanged: int(row.UnchangedCount),
+		},
+		FailedCount: int(row.FailedCount),
+		Checkpoint:  int(row.CheckpointPokedexID),
+		ParentID:    parentID,
+		Targets: pokemon.ImportTargets{
+			PokedexIDs: intSlice(row.PokedexIds),
+			From:       int(row.FromPokedexID.Int32),
+			To:         int(row.ToPokedexID.Int32),
+		},
+		ExpectedCount: int(row.ExpectedCount),
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+		StartedAt:     timeFromPG(row.StartedAt),
+		FinishedAt:    timeFromPG(row.FinishedAt),
+		ResumeCount:   int(row.ResumeCount),
+		Failure:       failure,
 	}, nil
 }
 
@@ -344,6 +1392,7 @@ func toCorePokemon(row sqlcgen.Pokemon) pokemon.Pokemon {
 		IsMythical:     row.IsMythical,
 		CreatedAt:      row.CreatedAt.Time,
 		UpdatedAt:      row.UpdatedAt.Time,
+		DeletedAt:      timeFromPG(row.DeletedAt),
 	}
 }
 
@@ -366,6 +1415,27 @@ func toCorePokemonFromCatch(row sqlcgen.GetCatchRow) pokemon.Pokemon {
 		IsMythical:     row.IsMythical,
 		CreatedAt:      row.CreatedAt.Time,
 		UpdatedAt:      row.UpdatedAt.Time,
+		DeletedAt:      timeFromPG(row.DeletedAt),
+	}
+}
+
+func toCorePokemonFromUpload(row sqlcgen.ImportUpload) pokemon.Pokemon {
+	return pokemon.Pokemon{
+		PokedexID:      int(row.PokedexID),
+		Name:           row.Name,
+		Rarity:         pokemon.Rarity(row.Rarity),
+		Types:          row.Types,
+		SpriteURL:      row.SpriteUrl,
+		HP:             int(row.Hp),
+		Attack:         int(row.Attack),
+		Defense:        int(row.Defense),
+		SpecialAttack:  int(row.SpecialAttack),
+		SpecialDefense: int(row.SpecialDefense),
+		Speed:          int(row.Speed),
+		BaseExperience: int(row.BaseExperience),
+		CaptureRate:    int(row.CaptureRate),
+		IsLegendary:    row.IsLegendary,
+		IsMythical:     row.IsMythical,
 	}
 }
 
@@ -390,6 +1460,7 @@ func toCorePokemonSlice(rows []sqlcgen.Pokemon) []pokemon.Pokemon {
 			IsMythical:     row.IsMythical,
 			CreatedAt:      row.CreatedAt.Time,
 			UpdatedAt:      row.UpdatedAt.Time,
+			DeletedAt:      timeFromPG(row.DeletedAt),
 		}
 	}
 
@@ -0,0 +1,195 @@
+// Package snapshotfile reads and writes catalog snapshots as checksummed JSON
+// files.
+package snapshotfile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reference-service-go/internal/core/pokemon"
+	"time"
+)
+
+const (
+	// format identifies snapshot files.
+	format = "reference-service-go/pokemon-snapshot"
+	// Version is the snapshot file version written by Write. Read accepts
+	// files of this version only.
+	Version = 1
+)
+
+var (
+	// ErrInvalidFormat is returned for files that are not snapshots.
+	ErrInvalidFormat = errors.New("not a pokemon snapshot")
+	// ErrUnsupportedVersion is returned for snapshots of another version.
+	ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+	// ErrChecksumMismatch is returned for snapshots whose Pokemon do not match
+	// their checksum or count.
+	ErrChecksumMismatch = errors.New("snapshot checksum mismatch")
+)
+
+// header is the part of a snapshot file describing its Pokemon.
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Count     int       `json:"count"`
+	// SHA256 is the hex-encoded checksum of the compact JSON of the Pokemon.
+	SHA256 string `json:"sha256"`
+}
+
+// file is a snapshot file.
+type file struct {
+	header
+
+	Pokemon json.RawMessage `json:"pokemon"`
+}
+
+// snapshotPokemon is a single Pokemon of a snapshot.
+type snapshotPokemon struct {
+	PokedexID      int      `json:"pokedex_id"`
+	Name           string   `json:"name"`
+	Rarity         string   `json:"rarity"`
+	Types          []string `json:"types"`
+	SpriteURL      string   `json:"sprite_url"`
+	HP             int      `json:"hp"`
+	Attack         int      `json:"attack"`
+	Defense        int      `json:"defense"`
+	SpecialAttack  int      `json:"special_attack"`
+	SpecialDefense int      `json:"special_defense"`
+	Speed          int      `json:"speed"`
+	BaseExperience int      `json:"base_experience"`
+	CaptureRate    int      `json:"capture_rate"`
+	IsLegendary    bool     `json:"is_legendary"`
+	IsMythical     bool     `json:"is_mythical"`
+}
+
+// Write encodes the snapshot to w.
+func Write(w io.Writer, snapshot pokemon.Snapshot) error {
+	rows := make([]snapshotPokemon, 0, len(snapshot.Pokemon))
+	for _, p := range snapshot.Pokemon {
+		rows = append(rows, fromPokemon(p))
+	}
+
+	raw, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("encoding pokemon: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err = encoder.Encode(file{
+		header: header{
+			Format:    format,
+			Version:   Version,
+			CreatedAt: snapshot.CreatedAt,
+			Count:     len(rows),
+			SHA256:    checksum(raw),
+		},
+		Pokemon: raw,
+	})
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Read decodes a snapshot from r after checking its version and checksum.
+func Read(r io.Reader) (pokemon.Snapshot, error) {
+	var snapshotFile file
+
+	err := json.NewDecoder(r).Decode(&snapshotFile)
+	if err != nil {
+		return pokemon.Snapshot{}, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	if snapshotFile.Format != format {
+		return pokemon.Snapshot{}, fmt.Errorf("%w: format %q", ErrInvalidFormat, snapshotFile.Format)
+	}
+
+	if snapshotFile.Version != Version {
+		return pokemon.Snapshot{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, snapshotFile.Version)
+	}
+
+	var compact bytes.Buffer
+
+	err = json.Compact(&compact, snapshotFile.Pokemon)
+	if err != nil {
+		return pokemon.Snapshot{}, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	if checksum(compact.Bytes()) != snapshotFile.SHA256 {
+		return pokemon.Snapshot{}, ErrChecksumMismatch
+	}
+
+	var rows []snapshotPokemon
+
+	err = json.Unmarshal(compact.Bytes(), &rows)
+	if err != nil {
+		return pokemon.Snapshot{}, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	if len(rows) != snapshotFile.Count {
+		return pokemon.Snapshot{}, fmt.Errorf("%w: %d pokemon, expected %d",
+			ErrChecksumMismatch, len(rows), snapshotFile.Count)
+	}
+
+	snapshot := pokemon.Snapshot{CreatedAt: snapshotFile.CreatedAt, Pokemon: make([]pokemon.Pokemon, 0, len(rows))}
+	for _, row := range rows {
+		snapshot.Pokemon = append(snapshot.Pokemon, row.toPokemon())
+	}
+
+	return snapshot, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func fromPokemon(p pokemon.Pokemon) snapshotPokemon {
+	return snapshotPokemon{
+		PokedexID:      p.PokedexID,
+		Name:           p.Name,
+		Rarity:         string(p.Rarity),
+		Types:          p.Types,
+		SpriteURL:      p.SpriteURL,
+		HP:             p.HP,
+		Attack:         p.Attack,
+		Defense:        p.Defense,
+		SpecialAttack:  p.SpecialAttack,
+		SpecialDefense: p.SpecialDefense,
+		Speed:          p.Speed,
+		BaseExperience: p.BaseExperience,
+		CaptureRate:    p.CaptureRate,
+		IsLegendary:    p.IsLegendary,
+		IsMythical:     p.IsMythical,
+	}
+}
+
+func (p snapshotPokemon) toPokemon() pokemon.Pokemon {
+	return pokemon.Pokemon{
+		PokedexID:      p.PokedexID,
+		Name:           p.Name,
+		Rarity:         pokemon.Rarity(p.Rarity),
+		Types:          p.Types,
+		SpriteURL:      p.SpriteURL,
+		HP:             p.HP,
+		Attack:         p.Attack,
+		Defense:        p.Defense,
+		SpecialAttack:  p.SpecialAttack,
+		SpecialDefense: p.SpecialDefense,
+		Speed:          p.Speed,
+		BaseExperience: p.BaseExperience,
+		CaptureRate:    p.CaptureRate,
+		IsLegendary:    p.IsLegendary,
+		IsMythical:     p.IsMythical,
+	}
+}
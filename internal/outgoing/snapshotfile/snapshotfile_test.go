@@ -0,0 +1,95 @@
+package snapshotfile_test
+
+import (
+	"bytes"
+	"reference-service-go/internal/core/pokemon"
+	"reference-service-go/internal/outgoing/snapshotfile"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+)
+
+func TestSnapshotFile(t *testing.T) {
+	t.Parallel()
+
+	snapshot := pokemon.Snapshot{
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Pokemon: []pokemon.Pokemon{
+			{
+				PokedexID: 25, Name: "pikachu", Rarity: pokemon.RarityCommon, Types: []string{"electric"},
+				HP: 35, Attack: 55, Defense: 40, SpecialAttack: 50, SpecialDefense: 50, Speed: 90,
+				BaseExperience: 112, CaptureRate: 190,
+			},
+			{
+				PokedexID: 151, Name: "mew", Rarity: pokemon.RarityMythical, Types: []string{"psychic"},
+				HP: 100, CaptureRate: 45, IsMythical: true,
+			},
+		},
+	}
+
+	t.Run("reads what it writes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a written snapshot
+		var buf bytes.Buffer
+
+		err := snapshotfile.Write(&buf, snapshot)
+		testastic.NoError(t, err)
+
+		// when: reading it back
+		read, err := snapshotfile.Read(&buf)
+
+		// then: it holds the same pokemon
+		testastic.NoError(t, err)
+		testastic.Equal(t, snapshot.CreatedAt, read.CreatedAt)
+		testastic.DeepEqual(t, snapshot.Pokemon, read.Pokemon)
+	})
+
+	t.Run("rejects tampered pokemon", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a snapshot whose pokemon were edited after writing
+		var buf bytes.Buffer
+
+		err := snapshotfile.Write(&buf, snapshot)
+		testastic.NoError(t, err)
+
+		tampered := strings.Replace(buf.String(), `"hp": 35`, `"hp": 350`, 1)
+
+		// when: reading it
+		_, err = snapshotfile.Read(strings.NewReader(tampered))
+
+		// then: the checksum does not match
+		testastic.ErrorIs(t, err, snapshotfile.ErrChecksumMismatch)
+	})
+
+	t.Run("rejects other versions", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a snapshot of a newer version
+		var buf bytes.Buffer
+
+		err := snapshotfile.Write(&buf, snapshot)
+		testastic.NoError(t, err)
+
+		newer := strings.Replace(buf.String(), `"version": 1`, `"version": 2`, 1)
+
+		// when: reading it
+		_, err = snapshotfile.Read(strings.NewReader(newer))
+
+		// then: the version is unsupported
+		testastic.ErrorIs(t, err, snapshotfile.ErrUnsupportedVersion)
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		t.Parallel()
+
+		// when: reading a file that is not a snapshot
+		_, err := snapshotfile.Read(strings.NewReader(`{"count": 1}`))
+
+		// then: the format is invalid
+		testastic.ErrorIs(t, err, snapshotfile.ErrInvalidFormat)
+	})
+}
@@ -40,19 +40,27 @@ func startPostgres(ctx context.Context) (*tcpostgres.PostgresContainer, error) {
 }
 
 func runMigrations(databaseURL string) error {
-	configPath, err := writeConfig("http://unused:0", 8080)
+	return runCommand(databaseURL, "-migrate-only")
+}
+
+// runCommand runs the service binary with the given mode flags against the
+// database and waits for it to exit.
+func runCommand(databaseURL string, args ...string) error {
+	configPath, err := writeConfig(serviceConfig{PokeAPIURL: "http://unused:0", Port: 8080})
 	if err != nil {
-		return fmt.Errorf("writing migration config: %w", err)
+		return fmt.Errorf("writing command config: %w", err)
 	}
 
 	defer os.Remove(configPath)
 
 	cmd := exec.Command(
-		"go", "run",
-		"-trimpath",
-		"-ldflags", "-X reference-service-go/internal/build.version=test",
-		"./cmd/reference-service-go",
-		"-config", configPath, "-migrate-only",
+		"go", append([]string{
+			"run",
+			"-trimpath",
+			"-ldflags", "-X reference-service-go/internal/build.version=test",
+			"./cmd/reference-service-go",
+			"-config", configPath,
+		}, args...)...,
 	)
 	cmd.Dir = ".."
 	cmd.Env = append(os.Environ(), databaseEnvVar+"="+databaseURL)
@@ -61,13 +69,23 @@ func runMigrations(databaseURL string) error {
 
 	err = cmd.Run()
 	if err != nil {
-		return fmt.Errorf("running migrations: %w", err)
+		return fmt.Errorf("running %v: %w", args, err)
 	}
 
 	return nil
 }
 
-func writeConfig(pokeapiURL string, port int) (string, error) {
+// serviceConfig holds the values rendered into the test config template.
+type serviceConfig struct {
+	PokeAPIURL string
+	Port       int
+	// ImportSchedule schedules a pokeapi import on the cron expression.
+	ImportSchedule string
+	// SnapshotPath is the snapshot loaded into an empty catalog at startup.
+	SnapshotPath string
+}
+
+func writeConfig(cfg serviceConfig) (string, error) {
 	tmpl, err := template.ParseFiles("testdata/config/config.yaml.tmpl")
 	if err != nil {
 		return "", fmt.Errorf("parse config template: %w", err)
@@ -79,13 +97,12 @@ func writeConfig(pokeapiURL string, port int) (string, error) {
 	}
 
 	err = tmpl.Execute(f, struct {
+		serviceConfig
+
 		DatabaseEnvVar string
-		PokeAPIURL     string
-		Port           int
 	}{
+		serviceConfig:  cfg,
 		DatabaseEnvVar: databaseEnvVar,
-		PokeAPIURL:     pokeapiURL,
-		Port:           port,
 	})
 	if err != nil {
 		f.Close()
@@ -119,10 +136,26 @@ func findFreePort(t *testing.T) int {
 func startService(t *testing.T, pokeapiURL string) *testastic.Process {
 	t.Helper()
 
-	port := findFreePort(t)
+	return startScheduledService(t, pokeapiURL, "")
+}
+
+// startScheduledService starts the service with a pokeapi import scheduled on
+// the given cron expression. An empty expression schedules nothing.
+func startScheduledService(t *testing.T, pokeapiURL string, importSchedule string) *testastic.Process {
+	t.Helper()
+
+	return startConfiguredService(t, serviceConfig{PokeAPIURL: pokeapiURL, ImportSchedule: importSchedule})
+}
+
+// startConfiguredService starts the service with the given config on a free
+// port.
+func startConfiguredService(t *testing.T, cfg serviceConfig) *testastic.Process {
+	t.Helper()
+
+	cfg.Port = findFreePort(t)
 	t.Setenv(databaseEnvVar, postgresURL)
 
-	configPath, err := writeConfig(pokeapiURL, port)
+	configPath, err := writeConfig(cfg)
 	if err != nil {
 		t.Fatalf("writing config: %v", err)
 	}
@@ -131,8 +164,8 @@ func startService(t *testing.T, pokeapiURL string) *testastic.Process {
 
 	return testastic.StartProcess(t.Context(), t,
 		"reference-service-go/cmd/reference-service-go",
-		testastic.HTTPCheck(port, "/health/ready"),
-		testastic.WithPort(port),
+		testastic.HTTPCheck(cfg.Port, "/health/ready"),
+		testastic.WithPort(cfg.Port),
 		testastic.WithArgs("-config", configPath),
 		testastic.WithBuildArgs("-trimpath", "-ldflags", "-X reference-service-go/internal/build.version=test"),
 		testastic.WithReadyTimeout(10*time.Second),
@@ -142,7 +175,10 @@ func startService(t *testing.T, pokeapiURL string) *testastic.Process {
 func truncateTables(t *testing.T) {
 	t.Helper()
 
-	_, err := testPool.Exec(context.Background(), "TRUNCATE TABLE catches, pokemon, imports")
+	_, err := testPool.Exec(context.Background(),
+		"TRUNCATE TABLE catches, pokemon, pokemon_history, import_errors, import_uploads, import_diffs, "+
+			"import_schedule_runs, imports, pokeapi_responses",
+	)
 	if err != nil {
 		t.Fatalf("truncating tables: %v", err)
 	}
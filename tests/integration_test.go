@@ -3,9 +3,15 @@
 package integration_test
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -141,6 +147,1075 @@ func TestImportFlow(t *testing.T) {
 	testastic.AssertJSON(t, "testdata/import_flow/get_bulbasaur_response.json", readBody(t, resp))
 }
 
+func TestRepeatImportRevalidatesCachedResponses(t *testing.T) {
+	// given: a catalog filled by a full import whose responses were cached
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importPokemonForSetup(t, proc.URL())
+	testastic.Equal(t, int32(4), mock.bodiesServed.Load())
+
+	// when: the same import runs again
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the unchanged responses are revalidated instead of downloaded again
+	testastic.Equal(t, int32(4), mock.bodiesServed.Load())
+
+	var cached int
+
+	err := testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM pokeapi_responses`).Scan(&cached)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 5, cached)
+}
+
+func TestImportResumesAfterLeaseExpiry(t *testing.T) {
+	// given: a processing import whose worker died after persisting the first pokemon
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importID := uuid.Must(uuid.NewV7()).String()
+
+	_, err := testPool.Exec(context.Background(), `
+		INSERT INTO imports (id, source, status, item_count, lease_owner, lease_expires_at, checkpoint_pokedex_id)
+		VALUES ($1, 'pokeapi', 'processing', 1, 'crashed-worker', NOW() - INTERVAL '1 minute', 1)`,
+		importID,
+	)
+	testastic.NoError(t, err)
+
+	// when: a service starts and its worker polls the queue
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// then: the expired lease is reclaimed and the import resumes after its checkpoint
+	awaitImportStatus(t, proc.URL(), importID, "completed")
+
+	resp := doGet(t, proc.URL()+"/imports/"+importID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_resumed_after_lease_expiry/response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/pokemon")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_resumed_after_lease_expiry/list_pokemon_response.json", readBody(t, resp))
+}
+
+func TestStaleImportFailedAfterMaxResumes(t *testing.T) {
+	// given: a processing import that was already resumed as often as allowed and has not been touched since
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importID := uuid.Must(uuid.NewV7()).String()
+
+	_, err := testPool.Exec(context.Background(), `
+		INSERT INTO imports (
+			id, source, status, item_count, checkpoint_pokedex_id,
+			lease_owner, lease_expires_at, resume_count, updated_at
+		)
+		VALUES (
+			$1, 'pokeapi', 'processing', 1, 1,
+			'crashed-worker', NOW() - INTERVAL '1 minute', 2, NOW() - INTERVAL '1 hour'
+		)`,
+		importID,
+	)
+	testastic.NoError(t, err)
+
+	// when: a service starts and reconciles stale imports
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// then: the import is failed in the recovery phase instead of resumed again
+	awaitImportStatus(t, proc.URL(), importID, "failed")
+
+	failure := getImportFailure(t, proc.URL(), importID)
+	testastic.Equal(t, "recovery", failure.Phase)
+	testastic.Contains(t, failure.Message, "abandoned after 2 resumes")
+	testastic.Equal(t, 1, failure.PersistedCount)
+}
+
+func TestImportInterruptedOnShutdownResumes(t *testing.T) {
+	// given: a processing import whose PokeAPI fetches outlast the drain timeout
+	stalled := newPokeAPIMock(t,
+		withSpeciesCount(2),
+		withPokemonDelay(time.Minute),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+	)
+
+	proc := startService(t, stalled.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "processing")
+
+	// when: the service shuts down
+	proc.Stop()
+
+	// then: the import is released as interrupted instead of waiting for its lease to expire
+	var status string
+
+	err := testPool.QueryRow(context.Background(),
+		"SELECT status FROM imports WHERE id = $1 AND lease_owner IS NULL", importResp.ID,
+	).Scan(&status)
+	testastic.NoError(t, err)
+	testastic.Equal(t, "interrupted", status)
+
+	// and: the next service resumes and completes it
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+	next := startService(t, mock.server.URL+"/api/v2")
+
+	awaitImportStatus(t, next.URL(), importResp.ID, "completed")
+}
+
+func TestFullImportDeactivatesRemovedSpecies(t *testing.T) {
+	// given: an imported catalog with a catch of the second species
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importPokemonForSetup(t, proc.URL())
+	proc.Stop()
+
+	catchID := uuid.Must(uuid.NewV7()).String()
+
+	_, err := testPool.Exec(context.Background(), `
+		INSERT INTO catches (id, pokemon_pokedex_id, pokeball_type, is_shiny, caught_at)
+		VALUES ($1, 2, 'pokeball', FALSE, NOW())`,
+		catchID,
+	)
+	testastic.NoError(t, err)
+
+	// when: a full import no longer finds the second species upstream
+	shrunk := newPokeAPIMock(t,
+		withSpeciesCount(1),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+	)
+	next := startService(t, shrunk.server.URL+"/api/v2")
+
+	importPokemonForSetup(t, next.URL())
+
+	// then: the species is no longer listed by default
+	var active pokemonListResponse
+
+	resp := doGet(t, next.URL()+"/pokemon")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	decodeJSON(t, readBody(t, resp), &active)
+	testastic.Equal(t, 1, active.Total)
+	testastic.Equal(t, 1, active.Items[0].ID)
+
+	// and: it is still listed as inactive on request
+	var all pokemonListResponse
+
+	resp = doGet(t, next.URL()+"/pokemon?include_inactive=true")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	decodeJSON(t, readBody(t, resp), &all)
+	testastic.Equal(t, 2, all.Total)
+	testastic.Equal(t, 2, all.Items[1].ID)
+	testastic.NotNil(t, all.Items[1].DeletedAt)
+
+	// and: the catch referencing it keeps working
+	resp = doGet(t, next.URL()+"/catches/"+catchID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestFullImportDeactivatesSpeciesNotFoundUpstream(t *testing.T) {
+	// given: an imported catalog of two species
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importPokemonForSetup(t, proc.URL())
+	proc.Stop()
+
+	// when: a full import still counts the second species but PokeAPI answers 404 for it
+	removed := newPokeAPIMock(t,
+		withSpeciesCount(2),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+	)
+	next := startService(t, removed.server.URL+"/api/v2")
+
+	importPokemonForSetup(t, next.URL())
+
+	// then: the species is deactivated although the import recorded an error for it
+	var deletedAt *time.Time
+
+	err := testPool.QueryRow(context.Background(),
+		`SELECT deleted_at FROM pokemon WHERE pokedex_id = 2`,
+	).Scan(&deletedAt)
+	testastic.NoError(t, err)
+	testastic.NotNil(t, deletedAt)
+
+	var active pokemonListResponse
+
+	resp := doGet(t, next.URL()+"/pokemon")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	decodeJSON(t, readBody(t, resp), &active)
+	testastic.Equal(t, 1, active.Total)
+	testastic.Equal(t, 1, active.Items[0].ID)
+}
+
+func TestPokemonHistory(t *testing.T) {
+	// given: a catalog filled by a full import, after which PokeAPI rebalanced one species
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importPokemonForSetup(t, proc.URL())
+
+	withPokemonFixture("2",
+		"testdata/incremental_import/pokeapi_second_pokemon_rebalanced.json",
+		"testdata/import_flow/pokeapi_second_species.json",
+	)(t, mock)
+
+	// when: another full import runs to completion
+	importPokemonForSetup(t, proc.URL())
+
+	// then: the history of the rebalanced species lists the overwritten stat
+	resp := doGet(t, proc.URL()+"/pokemon/2/history")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/pokemon_history/history_response.json", readBody(t, resp))
+
+	// and: the species left as it was has no history
+	resp = doGet(t, proc.URL()+"/pokemon/1/history")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/pokemon_history/unchanged_history_response.json", readBody(t, resp))
+
+	// and: a species missing from the catalog has no history to list
+	resp = doGet(t, proc.URL()+"/pokemon/999/history")
+	testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestImportRecordsSkippedPokemon(t *testing.T) {
+	// given: a PokeAPI fake that reports three species but serves only two of them
+	mock := newPokeAPIMock(t,
+		withSpeciesCount(3),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+		withPokemonFixture("2",
+			"testdata/import_flow/pokeapi_second_pokemon.json",
+			"testdata/import_flow/pokeapi_second_species.json",
+		),
+	)
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the missing species is counted and listed with its error class
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_skipped_pokemon/import_response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID+"/errors")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_skipped_pokemon/errors_response.json", readBody(t, resp))
+}
+
+func TestImportRetriesTransientFailures(t *testing.T) {
+	// given: a PokeAPI fake that is unavailable for the first two pokemon requests
+	mock := newPokeAPIMock(t,
+		withSpeciesCount(2),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+		withPokemonFixture("2",
+			"testdata/import_flow/pokeapi_second_pokemon.json",
+			"testdata/import_flow/pokeapi_second_species.json",
+		),
+		withTransientPokemonFailures(2),
+	)
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the failed requests were retried and no species was skipped
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_flow/completed_import_response.json", readBody(t, resp))
+}
+
+func TestImportFailsWhenSourceUnavailable(t *testing.T) {
+	// given: a PokeAPI fake that keeps failing every pokemon request
+	mock := newPokeAPIMock(t,
+		withSpeciesCount(50),
+		withTransientPokemonFailures(1000),
+	)
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import runs into the open circuit breaker
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+
+	// then: the import fails instead of skipping every species
+	awaitImportStatus(t, proc.URL(), importResp.ID, "failed")
+
+	// and: the failure points at the source rather than the database
+	failure := getImportFailure(t, proc.URL(), importResp.ID)
+	testastic.Equal(t, "fetch", failure.Phase)
+	testastic.Contains(t, failure.Message, "import source unavailable")
+	testastic.Equal(t, 0, failure.PersistedCount)
+
+	resp = doGet(t, proc.URL()+"/health/ready")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var ready struct {
+		Checks []struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		} `json:"checks"`
+	}
+
+	decodeJSON(t, readBody(t, resp), &ready)
+
+	messages := make(map[string]string, len(ready.Checks))
+	for _, check := range ready.Checks {
+		messages[check.Name] = check.Message
+	}
+
+	testastic.Contains(t, messages["import_source_pokeapi"], "circuit breaker open")
+	testastic.Equal(t, "circuit breaker closed", messages["import_source_mirror"])
+}
+
+func TestImportFailsWhenSpeciesCountUnavailable(t *testing.T) {
+	// given: a PokeAPI fake that cannot tell how many species there are
+	mock := newPokeAPIMock(t, withSpeciesCountFailure())
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import of every species runs
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+
+	// then: the import fails before fetching any species
+	awaitImportStatus(t, proc.URL(), importResp.ID, "failed")
+
+	failure := getImportFailure(t, proc.URL(), importResp.ID)
+	testastic.Equal(t, "species_count", failure.Phase)
+	testastic.Contains(t, failure.Message, "fetching species count")
+	testastic.Equal(t, 0, failure.PersistedCount)
+}
+
+func TestImportFailsWhenCatalogRejectsWrite(t *testing.T) {
+	// given: a catalog that rejects every new species
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+
+	_, err := testPool.Exec(context.Background(), `
+		CREATE FUNCTION reject_pokemon() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'pokemon % rejected', NEW.pokedex_id;
+		END;
+		$$ LANGUAGE plpgsql`,
+	)
+	testastic.NoError(t, err)
+
+	_, err = testPool.Exec(context.Background(),
+		`CREATE TRIGGER reject_pokemon BEFORE INSERT ON pokemon FOR EACH ROW EXECUTE FUNCTION reject_pokemon()`,
+	)
+	testastic.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, dropErr := testPool.Exec(context.Background(), `DROP TRIGGER IF EXISTS reject_pokemon ON pokemon`)
+		testastic.NoError(t, dropErr)
+
+		_, dropErr = testPool.Exec(context.Background(), `DROP FUNCTION IF EXISTS reject_pokemon()`)
+		testastic.NoError(t, dropErr)
+
+		truncateTables(t)
+	})
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: an import runs
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+
+	// then: the import fails writing its first batch, pointing at the database
+	awaitImportStatus(t, proc.URL(), importResp.ID, "failed")
+
+	failure := getImportFailure(t, proc.URL(), importResp.ID)
+	testastic.Equal(t, "upsert", failure.Phase)
+	testastic.Contains(t, failure.Message, "pokemon 1 rejected")
+	testastic.Equal(t, 0, failure.PersistedCount)
+}
+
+func TestRetryImport(t *testing.T) {
+	// given: a completed import that skipped a species PokeAPI did not serve
+	mock := newPokeAPIMock(t,
+		withSpeciesCount(3),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+		withPokemonFixture("2",
+			"testdata/import_flow/pokeapi_second_pokemon.json",
+			"testdata/import_flow/pokeapi_second_species.json",
+		),
+	)
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var parent createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &parent)
+	awaitImportStatus(t, proc.URL(), parent.ID, "completed")
+
+	withPokemonFixture("3",
+		"testdata/open_pokeball_after_import/pokeapi_third_pokemon.json",
+		"testdata/open_pokeball_after_import/pokeapi_third_species.json",
+	)(t, mock)
+
+	// when: POST /imports/{id}/retry retries the failed species
+	resp = doPost(t, proc.URL()+"/imports/"+parent.ID+"/retry", "")
+
+	// then: a child import linked to its parent fetches only the failed species
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var child createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &child)
+	testastic.Equal(t, "/imports/"+child.ID, resp.Header.Get("Location"))
+	awaitImportStatus(t, proc.URL(), child.ID, "completed")
+
+	resp = doGet(t, proc.URL()+"/imports/"+child.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/retry_import/child_response.json", readBody(t, resp))
+
+	// and: a completed retry without failures has nothing left to retry
+	resp = doPost(t, proc.URL()+"/imports/"+child.ID+"/retry", "")
+	testastic.Equal(t, http.StatusConflict, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/retry_import/nothing_to_retry_response.json", readBody(t, resp))
+}
+
+func TestIncrementalImport(t *testing.T) {
+	// given: a catalog filled by a full import, after which PokeAPI changed one species
+	mock := newScenarioPokeAPIMock(t, "testdata/import_flow")
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importPokemonForSetup(t, proc.URL())
+
+	var bulbasaurUpdatedAt time.Time
+
+	err := testPool.QueryRow(context.Background(),
+		`SELECT updated_at FROM pokemon WHERE pokedex_id = 1`,
+	).Scan(&bulbasaurUpdatedAt)
+	testastic.NoError(t, err)
+
+	withPokemonFixture("2",
+		"testdata/incremental_import/pokeapi_second_pokemon_rebalanced.json",
+		"testdata/import_flow/pokeapi_second_species.json",
+	)(t, mock)
+
+	// when: an incremental import runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "mode": "incremental"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: only the changed species is written
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/incremental_import/import_response.json", readBody(t, resp))
+
+	var updatedAt time.Time
+
+	err = testPool.QueryRow(context.Background(),
+		`SELECT updated_at FROM pokemon WHERE pokedex_id = 1`,
+	).Scan(&updatedAt)
+	testastic.NoError(t, err)
+	testastic.True(t, updatedAt.Equal(bulbasaurUpdatedAt))
+}
+
+func TestDryRunImport(t *testing.T) {
+	// given: a catalog that drifted from PokeAPI after a full import
+	mock := newCatchAfterImportMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	importPokemonForSetup(t, proc.URL())
+
+	_, err := testPool.Exec(context.Background(), `
+		DELETE FROM pokemon WHERE pokedex_id = 151;
+		UPDATE pokemon SET rarity = 'rare', hp = 80 WHERE pokedex_id = 30;
+		INSERT INTO pokemon (pokedex_id, name, rarity, types, hp, attack, defense, special_attack, special_defense, speed)
+		VALUES (9999, 'missingno', 'common', '{bird,normal}', 33, 136, 0, 6, 6, 29)`,
+	)
+	testastic.NoError(t, err)
+
+	// when: a dry-run import runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "mode": "dry_run"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the import counts how it would have changed the catalog
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/dry_run_import/import_response.json", readBody(t, resp))
+
+	// and: the diff report lists new, removed and changed species
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID+"/diff")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/dry_run_import/diff_response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID+"/diff?kind=changed")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/dry_run_import/changed_diff_response.json", readBody(t, resp))
+
+	// and: the catalog is left untouched
+	var hp int
+
+	err = testPool.QueryRow(context.Background(), `SELECT hp FROM pokemon WHERE pokedex_id = 30`).Scan(&hp)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 80, hp)
+
+	resp = doGet(t, proc.URL()+"/pokemon/151")
+	testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestImportDiffNotDryRun(t *testing.T) {
+	// given: a completed full import
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	_, err := testPool.Exec(context.Background(), `
+		INSERT INTO imports (id, source, status, item_count)
+		VALUES ('0193a4c0-0000-7000-8000-000000000001', 'pokeapi', 'completed', 1025)`,
+	)
+	testastic.NoError(t, err)
+
+	// when: its diff report is requested
+	resp := doGet(t, proc.URL()+"/imports/0193a4c0-0000-7000-8000-000000000001/diff")
+
+	// then: the API returns a not found problem response
+	testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/dry_run_import/not_dry_run_response.json", readBody(t, resp))
+}
+
+func TestImportPokedexRange(t *testing.T) {
+	// given: a PokeAPI fake that serves five species
+	mock := newCatchAfterImportMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import of the range 2..3 runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "from": 2, "to": 3}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: only the species in the range are imported and the range is kept on the import
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_pokedex_range/import_response.json", readBody(t, resp))
+
+	var count int
+
+	err := testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM pokemon`).Scan(&count)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 2, count)
+}
+
+func TestImportRangePastSpeciesCount(t *testing.T) {
+	// given: a PokeAPI fake that serves five species
+	mock := newCatchAfterImportMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import of a range far past the last species runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "from": 4, "to": 2147483647}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the range stops at the species count
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_range_past_species_count/import_response.json", readBody(t, resp))
+
+	var count int
+
+	err := testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM pokemon`).Scan(&count)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 2, count)
+}
+
+func TestImportPokedexIDs(t *testing.T) {
+	// given: a PokeAPI fake that serves five species
+	mock := newCatchAfterImportMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import of two explicit Pokedex IDs runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "pokedex_ids": [5, 1]}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: only the listed species are imported
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_pokedex_ids/import_response.json", readBody(t, resp))
+
+	var count int
+
+	err := testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM pokemon`).Scan(&count)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 2, count)
+}
+
+func TestImportConfiguredSource(t *testing.T) {
+	// given: a service configured with a second source named mirror
+	mock := newCatchAfterImportMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: an import from the mirror source runs to completion
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "mirror", "pokedex_ids": [5, 1]}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the import records the source it was fetched from
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_configured_source/import_response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/imports?source=mirror")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.Contains(t, string(readBody(t, resp)), importResp.ID)
+}
+
+func TestScheduledImportAcrossReplicas(t *testing.T) {
+	// given: two replicas scheduling an import every second
+	mock := newCatchAfterImportMock(t)
+	startScheduledService(t, mock.server.URL+"/api/v2", "@every 1s")
+	startScheduledService(t, mock.server.URL+"/api/v2", "@every 1s")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	// when: the schedule has fired a few times
+	testastic.EventuallyTrue(t, func() bool {
+		var count int
+
+		err := testPool.QueryRow(context.Background(),
+			`SELECT COUNT(*) FROM imports WHERE status = 'completed'`,
+		).Scan(&count)
+
+		return err == nil && count >= 3
+	}, 30*time.Second)
+
+	// then: no tick created more than one import
+	var total, ticks int
+
+	err := testPool.QueryRow(context.Background(),
+		`SELECT COUNT(*), COUNT(DISTINCT date_trunc('second', created_at)) FROM imports`,
+	).Scan(&total, &ticks)
+	testastic.NoError(t, err)
+	testastic.Equal(t, ticks, total)
+
+	var runs int
+
+	err = testPool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM import_schedule_runs WHERE name = 'scheduled'`,
+	).Scan(&runs)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 1, runs)
+}
+
+func TestFileImport(t *testing.T) {
+	// given: a service that cannot reach PokeAPI and an NDJSON upload of two species
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	upload, err := os.ReadFile("testdata/file_import/upload.ndjson")
+	testastic.NoError(t, err)
+
+	// when: the upload is posted as the request body
+	resp := doUpload(t, proc.URL()+"/imports", "application/x-ndjson", bytes.NewReader(upload))
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the uploaded species are imported into the catalog
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/file_import/import_response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/pokemon")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_flow/list_pokemon_response.json", readBody(t, resp))
+}
+
+func TestFileImportMultipartCSV(t *testing.T) {
+	// given: a CSV file of two species in a multipart form
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	upload, err := os.ReadFile("testdata/file_import/upload.csv")
+	testastic.NoError(t, err)
+
+	var form bytes.Buffer
+
+	writer := multipart.NewWriter(&form)
+	part, err := writer.CreateFormFile("file", "pokemon.csv")
+	testastic.NoError(t, err)
+	_, err = part.Write(upload)
+	testastic.NoError(t, err)
+	testastic.NoError(t, writer.Close())
+
+	// when: the form is posted
+	resp := doUpload(t, proc.URL()+"/imports", writer.FormDataContentType(), &form)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "completed")
+
+	// then: the same species are imported as from an NDJSON upload
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/file_import/import_response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/pokemon")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_flow/list_pokemon_response.json", readBody(t, resp))
+}
+
+func TestFileImportInvalidRow(t *testing.T) {
+	// given: an NDJSON upload whose second row has an unknown rarity
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	upload, err := os.ReadFile("testdata/file_import_invalid_row/upload.ndjson")
+	testastic.NoError(t, err)
+
+	// when: the upload is posted
+	resp := doUpload(t, proc.URL()+"/imports", "application/x-ndjson", bytes.NewReader(upload))
+
+	// then: the whole upload is rejected with the offending line
+	testastic.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/file_import_invalid_row/response.json", readBody(t, resp))
+
+	var count int
+
+	err = testPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM imports`).Scan(&count)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 0, count)
+}
+
+func TestListImportErrorsNotFound(t *testing.T) {
+	// given: a running service with no matching import
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: GET /imports/{id}/errors is called for a missing import
+	resp := doGet(t, proc.URL()+"/imports/550e8400-e29b-41d4-a716-446655440000/errors")
+
+	// then: the API returns a not found problem response
+	testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/get_import_not_found/response.json", readBody(t, resp))
+}
+
+func TestCancelImport(t *testing.T) {
+	// given: a processing import whose PokeAPI fetches never finish in time
+	mock := newPokeAPIMock(t,
+		withSpeciesCount(2),
+		withPokemonDelay(time.Minute),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+	)
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+	awaitImportStatus(t, proc.URL(), importResp.ID, "processing")
+	awaitImportExpectedCount(t, proc.URL(), importResp.ID, 2)
+
+	// when: DELETE /imports/{id} cancels the running import
+	resp = doDelete(t, proc.URL()+"/imports/"+importResp.ID)
+
+	// then: the import is cancelled and stays cancelled
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/cancel_import/response.json", readBody(t, resp))
+
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/cancel_import/response.json", readBody(t, resp))
+
+	// and: cancelling it again is rejected
+	resp = doDelete(t, proc.URL()+"/imports/"+importResp.ID)
+	testastic.Equal(t, http.StatusConflict, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/cancel_import/already_finished_response.json", readBody(t, resp))
+}
+
+func TestCreateImportInFlight(t *testing.T) {
+	// given: a processing import whose PokeAPI fetches never finish in time
+	mock := newPokeAPIMock(t,
+		withSpeciesCount(2),
+		withPokemonDelay(time.Minute),
+		withPokemonFixture("1",
+			"testdata/import_flow/pokeapi_first_pokemon.json",
+			"testdata/import_flow/pokeapi_first_species.json",
+		),
+	)
+
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var active createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &active)
+	awaitImportStatus(t, proc.URL(), active.ID, "processing")
+
+	// when: another import of the same source is requested
+	resp = doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "pokedex_ids": [1]}`)
+
+	// then: it is rejected with a link to the in-flight import
+	testastic.Equal(t, http.StatusConflict, resp.StatusCode)
+	testastic.Equal(t, "/imports/"+active.ID, resp.Header.Get("Location"))
+	testastic.Contains(t, string(readBody(t, resp)), active.ID)
+
+	// when: the request asks to join the in-flight import
+	resp = doPost(t, proc.URL()+"/imports?join=true", `{"source": "pokeapi", "pokedex_ids": [1]}`)
+
+	// then: the in-flight import is returned instead of a new one
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.Equal(t, "/imports/"+active.ID, resp.Header.Get("Location"))
+
+	var joined createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &joined)
+	testastic.Equal(t, active.ID, joined.ID)
+
+	// and: an import of another source is not blocked
+	resp = doPost(t, proc.URL()+"/imports", `{"source": "mirror", "pokedex_ids": [1]}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var count int
+
+	err := testPool.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM imports WHERE source = 'pokeapi'`,
+	).Scan(&count)
+	testastic.NoError(t, err)
+	testastic.Equal(t, 1, count)
+}
+
+func TestImportEvents(t *testing.T) {
+	// given: a running import of five species
+	mock := newCatchAfterImportMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi"}`)
+	testastic.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var importResp createdImportResponse
+
+	decodeJSON(t, readBody(t, resp), &importResp)
+
+	// when: the events of the import are streamed
+	resp = doGet(t, proc.URL()+"/imports/"+importResp.ID+"/events")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := readEvents(t, resp)
+
+	// then: the stream starts with a status event and closes after the import completed
+	testastic.True(t, len(events) > 0)
+	testastic.Equal(t, "status", events[0].name)
+
+	last := events[len(events)-1]
+	testastic.Equal(t, "status", last.name)
+	testastic.AssertJSON(t, "testdata/import_events/completed_event.json", []byte(last.data))
+}
+
+func TestImportEventsNotFound(t *testing.T) {
+	// given: a running service
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: the events of a non-existent import are requested
+	resp := doGet(t, proc.URL()+"/imports/00000000-0000-0000-0000-000000000000/events")
+
+	// then: it returns a not found problem
+	testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_events/not_found_response.json", readBody(t, resp))
+}
+
+func TestCancelImportNotFound(t *testing.T) {
+	// given: a running service with no matching import
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: DELETE /imports/{id} is called for a missing import
+	resp := doDelete(t, proc.URL()+"/imports/550e8400-e29b-41d4-a716-446655440000")
+
+	// then: the API returns a not found problem response
+	testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/get_import_not_found/response.json", readBody(t, resp))
+}
+
+func TestListImports(t *testing.T) {
+	// given: a running service with a history of finished imports
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+
+	_, err := testPool.Exec(context.Background(), `
+		INSERT INTO imports (id, source, status, item_count, created_at, updated_at) VALUES
+		('0193a4c0-0000-7000-8000-000000000001', 'pokeapi', 'completed', 1025, '2025-01-01T08:00:00Z', '2025-01-01T08:05:00Z'),
+		('0193a4c0-0000-7000-8000-000000000002', 'pokeapi', 'failed', 0, '2025-01-02T08:00:00Z', '2025-01-02T08:01:00Z'),
+		('0193a4c0-0000-7000-8000-000000000003', 'pokeapi', 'completed', 1025, '2025-01-03T08:00:00Z', '2025-01-03T08:05:00Z')`,
+	)
+	testastic.NoError(t, err)
+
+	// when: GET /imports is called without filters
+	resp := doGet(t, proc.URL()+"/imports?limit=2")
+
+	// then: the first page of imports is returned newest first
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/list_imports/first_page_response.json", readBody(t, resp))
+
+	// when: GET /imports is filtered by status and creation time
+	resp = doGet(t, proc.URL()+"/imports?status=completed&created_before=2025-01-02T00:00:00Z")
+
+	// then: only the matching import is returned
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/list_imports/filtered_response.json", readBody(t, resp))
+}
+
+func TestListImportsInvalidStatus(t *testing.T) {
+	// given: a running service
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: GET /imports is filtered by an unknown status
+	resp := doGet(t, proc.URL()+"/imports?status=exploded")
+
+	// then: the API rejects the filter
+	testastic.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/list_imports/invalid_status_response.json", readBody(t, resp))
+}
+
 func TestListPokemonEmpty(t *testing.T) {
 	// given: a running service with an empty database
 	mock := newPokeAPIMock(t)
@@ -257,6 +1332,43 @@ func TestCreateCatchAfterImport(t *testing.T) {
 	testastic.AssertJSON(t, "testdata/get_catch/existing/response.json", readBody(t, getResp))
 }
 
+func TestSnapshotExportAndLoad(t *testing.T) {
+	// given: a service with imported pokemon exported to a snapshot
+	mock := newScenarioPokeAPIMock(t, "testdata/snapshot")
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	t.Cleanup(func() { truncateTables(t) })
+	importPokemonForSetup(t, proc.URL())
+
+	snapshotPath := filepath.Join(t.TempDir(), "pokemon.snapshot.json")
+
+	err := runCommand(postgresURL, "-export-snapshot", snapshotPath)
+	testastic.NoError(t, err)
+
+	// when: loading the snapshot into the catalog it was exported from
+	err = runCommand(postgresURL, "-load-snapshot", snapshotPath)
+
+	// then: the catalog is not empty, so the snapshot is rejected
+	testastic.Error(t, err)
+
+	// when: a service starts on an empty database with the snapshot configured
+	truncateTables(t)
+
+	seeded := startConfiguredService(t, serviceConfig{
+		PokeAPIURL:   mock.server.URL + "/api/v2",
+		SnapshotPath: snapshotPath,
+	})
+
+	// then: the catalog is seeded before any import and pokemon can be caught
+	resp := doGet(t, seeded.URL()+"/pokemon?limit=1")
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/snapshot/list_response.json", readBody(t, resp))
+
+	catchResp := doPost(t, seeded.URL()+"/catches", `{"pokeball_type": "pokeball"}`)
+	testastic.Equal(t, http.StatusCreated, catchResp.StatusCode)
+	readBody(t, catchResp)
+}
+
 func TestCreateImportInvalidBody(t *testing.T) {
 	// given: a running service
 	mock := newPokeAPIMock(t)
@@ -283,6 +1395,46 @@ func TestCreateImportUnsupportedSource(t *testing.T) {
 	testastic.AssertJSON(t, "testdata/import_unsupported_source/response.json", readBody(t, resp))
 }
 
+func TestCreateImportUnsupportedMode(t *testing.T) {
+	// given: a running service
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: POST /imports is called with an unsupported mode
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "mode": "partial"}`)
+
+	// then: the API rejects the unsupported mode value
+	testastic.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_unsupported_mode/response.json", readBody(t, resp))
+}
+
+func TestCreateImportInvalidTargets(t *testing.T) {
+	// given: a running service
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: POST /imports is called with both Pokedex IDs and a range
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "pokedex_ids": [1], "from": 2}`)
+
+	// then: the API rejects the ambiguous targets
+	testastic.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_invalid_targets/response.json", readBody(t, resp))
+}
+
+func TestCreateImportTooManyPokedexIDs(t *testing.T) {
+	// given: a running service
+	mock := newPokeAPIMock(t)
+	proc := startService(t, mock.server.URL+"/api/v2")
+
+	// when: POST /imports lists more Pokedex IDs than one import may fetch
+	ids := strings.TrimSuffix(strings.Repeat("1, ", 1001), ", ")
+	resp := doPost(t, proc.URL()+"/imports", `{"source": "pokeapi", "pokedex_ids": [`+ids+`]}`)
+
+	// then: the API rejects the request
+	testastic.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	testastic.AssertJSON(t, "testdata/import_too_many_pokedex_ids/response.json", readBody(t, resp))
+}
+
 func TestGetImportNotFound(t *testing.T) {
 	// given: a running service with no matching import
 	mock := newPokeAPIMock(t)
@@ -319,8 +1471,113 @@ type createdCatchResponse struct {
 	ID string `json:"id"`
 }
 
+type pokemonListResponse struct {
+	Items []struct {
+		ID        int     `json:"id"`
+		DeletedAt *string `json:"deleted_at"`
+	} `json:"items"`
+	Total int `json:"total"`
+}
+
 type importStatusResponse struct {
-	Status string `json:"status"`
+	Status        string `json:"status"`
+	ExpectedCount int    `json:"expected_count"`
+}
+
+type importFailureResponse struct {
+	Phase          string `json:"phase"`
+	Message        string `json:"message"`
+	PersistedCount int    `json:"persisted_count"`
+}
+
+// getImportFailure returns the failure of a failed import.
+func getImportFailure(t *testing.T, procURL string, importID string) importFailureResponse {
+	t.Helper()
+
+	resp := doGet(t, procURL+"/imports/"+importID)
+	testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var imp struct {
+		Failure *importFailureResponse `json:"failure"`
+	}
+
+	decodeJSON(t, readBody(t, resp), &imp)
+
+	if imp.Failure == nil {
+		t.Fatalf("failed import %s has no failure", importID)
+	}
+
+	return *imp.Failure
+}
+
+func awaitImportStatus(t *testing.T, procURL string, importID string, want string) {
+	t.Helper()
+
+	testastic.EventuallyEqual(t, want, func() string {
+		resp := doGet(t, procURL+"/imports/"+importID)
+		body := readBody(t, resp)
+
+		var status importStatusResponse
+
+		decodeJSON(t, body, &status)
+
+		return status.Status
+	}, 30*time.Second)
+}
+
+func awaitImportExpectedCount(t *testing.T, procURL string, importID string, want int) {
+	t.Helper()
+
+	testastic.EventuallyEqual(t, want, func() int {
+		resp := doGet(t, procURL+"/imports/"+importID)
+		body := readBody(t, resp)
+
+		var status importStatusResponse
+
+		decodeJSON(t, body, &status)
+
+		return status.ExpectedCount
+	}, 30*time.Second)
+}
+
+// serverSentEvent is a single event read from a text/event-stream response.
+type serverSentEvent struct {
+	name string
+	data string
+}
+
+// readEvents reads the events of a text/event-stream response until the server closes it.
+func readEvents(t *testing.T, resp *http.Response) []serverSentEvent {
+	t.Helper()
+
+	defer resp.Body.Close()
+
+	var (
+		events  []serverSentEvent
+		current serverSentEvent
+	)
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if current.name != "" || current.data != "" {
+				events = append(events, current)
+			}
+
+			current = serverSentEvent{}
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	testastic.NoError(t, scanner.Err())
+
+	return events
 }
 
 func assertUUIDV7(t *testing.T, raw string) {
@@ -355,6 +1612,31 @@ func doPost(t *testing.T, url string, body string) *http.Response {
 	return resp
 }
 
+func doUpload(t *testing.T, url string, contentType string, body io.Reader) *http.Response {
+	t.Helper()
+
+	resp, err := http.Post(url, contentType, body) //nolint:noctx // Test code.
+	testastic.NoError(t, err)
+
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func doDelete(t *testing.T, url string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil) //nolint:noctx // Test code.
+	testastic.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	testastic.NoError(t, err)
+
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
 func readBody(t *testing.T, resp *http.Response) []byte {
 	t.Helper()
 
@@ -3,12 +3,15 @@
 package integration_test
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 type pokeAPIMock struct {
@@ -16,8 +19,14 @@ type pokeAPIMock struct {
 
 	mu               sync.RWMutex
 	speciesCount     int
+	speciesCountFail bool
 	pokemonResponses map[string]string
 	speciesResponses map[string]string
+	pokemonDelay     time.Duration
+	pokemonFailures  int
+	// bodiesServed counts pokemon and species responses served with a body
+	// rather than 304 Not Modified.
+	bodiesServed atomic.Int32
 }
 
 type pokeAPIMockOption func(t *testing.T, mock *pokeAPIMock)
@@ -39,8 +48,15 @@ func newPokeAPIMock(t *testing.T, opts ...pokeAPIMockOption) *pokeAPIMock {
 	mux.HandleFunc("GET /api/v2/pokemon-species", func(w http.ResponseWriter, _ *http.Request) {
 		mock.mu.RLock()
 		count := mock.speciesCount
+		fail := mock.speciesCountFail
 		mock.mu.RUnlock()
 
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = fmt.Fprintf(w, `{"count": %d, "results": []}`, count)
 	})
@@ -48,9 +64,24 @@ func newPokeAPIMock(t *testing.T, opts ...pokeAPIMockOption) *pokeAPIMock {
 	mux.HandleFunc("GET /api/v2/pokemon/{id}", func(w http.ResponseWriter, r *http.Request) {
 		id := r.PathValue("id")
 
-		mock.mu.RLock()
+		mock.mu.Lock()
 		body, ok := mock.pokemonResponses[id]
-		mock.mu.RUnlock()
+		delay := mock.pokemonDelay
+		fail := mock.pokemonFailures > 0
+		mock.pokemonFailures--
+		mock.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
 
 		if !ok {
 			w.WriteHeader(http.StatusNotFound)
@@ -58,8 +89,7 @@ func newPokeAPIMock(t *testing.T, opts ...pokeAPIMockOption) *pokeAPIMock {
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = fmt.Fprint(w, body)
+		mock.serveJSON(w, r, body)
 	})
 
 	mux.HandleFunc("GET /api/v2/pokemon-species/{id}", func(w http.ResponseWriter, r *http.Request) {
@@ -75,8 +105,7 @@ func newPokeAPIMock(t *testing.T, opts ...pokeAPIMockOption) *pokeAPIMock {
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = fmt.Fprint(w, body)
+		mock.serveJSON(w, r, body)
 	})
 
 	mock.server = httptest.NewServer(mux)
@@ -85,6 +114,24 @@ func newPokeAPIMock(t *testing.T, opts ...pokeAPIMockOption) *pokeAPIMock {
 	return mock
 }
 
+// serveJSON serves a body tagged with an ETag of its content, answering
+// requests that already hold it with 304 Not Modified.
+func (m *pokeAPIMock) serveJSON(w http.ResponseWriter, r *http.Request, body string) {
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256([]byte(body)))
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	m.bodiesServed.Add(1)
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprint(w, body)
+}
+
 func withSpeciesCount(count int) pokeAPIMockOption {
 	return func(_ *testing.T, mock *pokeAPIMock) {
 		mock.mu.Lock()
@@ -93,6 +140,16 @@ func withSpeciesCount(count int) pokeAPIMockOption {
 	}
 }
 
+// withSpeciesCountFailure answers every species count request with 500
+// Internal Server Error.
+func withSpeciesCountFailure() pokeAPIMockOption {
+	return func(_ *testing.T, mock *pokeAPIMock) {
+		mock.mu.Lock()
+		mock.speciesCountFail = true
+		mock.mu.Unlock()
+	}
+}
+
 func withPokemonFixture(id string, pokemonFile string, speciesFile string) pokeAPIMockOption {
 	return func(t *testing.T, mock *pokeAPIMock) {
 		t.Helper()
@@ -113,3 +170,21 @@ func withPokemonFixture(id string, pokemonFile string, speciesFile string) pokeA
 		mock.mu.Unlock()
 	}
 }
+
+func withPokemonDelay(delay time.Duration) pokeAPIMockOption {
+	return func(_ *testing.T, mock *pokeAPIMock) {
+		mock.mu.Lock()
+		mock.pokemonDelay = delay
+		mock.mu.Unlock()
+	}
+}
+
+// withTransientPokemonFailures answers the first failures pokemon requests with
+// 503 Service Unavailable.
+func withTransientPokemonFailures(failures int) pokeAPIMockOption {
+	return func(_ *testing.T, mock *pokeAPIMock) {
+		mock.mu.Lock()
+		mock.pokemonFailures = failures
+		mock.mu.Unlock()
+	}
+}